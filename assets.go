@@ -2,7 +2,12 @@ package gontentful
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -53,22 +58,288 @@ func (a *PGSQLAssetTable) Fields() []map[string]string {
 	return fields
 }
 
+// UnmarshalJSON reads the fields of single locale delivery responses into the sys.locale key
+func (a *Asset) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Sys      *Sys            `json:"sys"`
+		Metadata *Metadata       `json:"metadata"`
+		Fields   json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.Sys = raw.Sys
+	a.Metadata = raw.Metadata
+	a.Fields = nil
+	if len(raw.Fields) == 0 || string(raw.Fields) == "null" {
+		return nil
+	}
+
+	if raw.Sys == nil || raw.Sys.Locale == "" {
+		a.Fields = &AssetFields{}
+		return json.Unmarshal(raw.Fields, a.Fields)
+	}
+
+	var fields struct {
+		Title       *string    `json:"title"`
+		Description *string    `json:"description"`
+		File        *AssetFile `json:"file"`
+	}
+	if err := json.Unmarshal(raw.Fields, &fields); err != nil {
+		return err
+	}
+	locale := raw.Sys.Locale
+	a.Fields = &AssetFields{}
+	if fields.Title != nil {
+		a.Fields.Title = map[string]string{locale: *fields.Title}
+	}
+	if fields.Description != nil {
+		a.Fields.Description = map[string]string{locale: *fields.Description}
+	}
+	if fields.File != nil {
+		a.Fields.File = map[string]*AssetFile{locale: fields.File}
+	}
+	return nil
+}
+
 type AssetsService service
 
+func (s *AssetsService) Get(query url.Values) ([]byte, error) {
+	return s.GetContext(context.Background(), query)
+}
+
+func (s *AssetsService) GetContext(ctx context.Context, query url.Values) ([]byte, error) {
+	path := fmt.Sprintf(pathAssets, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	return s.client.get(ctx, path, query)
+}
+
+func (s *AssetsService) GetAssets(query url.Values) (*Assets, error) {
+	return s.GetAssetsContext(context.Background(), query)
+}
+
+func (s *AssetsService) GetAssetsContext(ctx context.Context, query url.Values) (*Assets, error) {
+	data, err := s.GetContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAssets(data)
+}
+
+func (s *AssetsService) GetSingle(assetId string) (*Asset, error) {
+	return s.GetSingleContext(context.Background(), assetId)
+}
+
+func (s *AssetsService) GetSingleContext(ctx context.Context, assetId string) (*Asset, error) {
+	path := fmt.Sprintf(pathAsset, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	data, err := s.client.get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+func (s *AssetsService) GetCMAAssets(query url.Values) (*Assets, error) {
+	return s.GetCMAAssetsContext(context.Background(), query)
+}
+
+func (s *AssetsService) GetCMAAssetsContext(ctx context.Context, query url.Values) (*Assets, error) {
+	path := fmt.Sprintf(pathAssets, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAssets(data)
+}
+
+func (s *AssetsService) GetSingleCMA(assetId string) (*Asset, error) {
+	return s.GetSingleCMAContext(context.Background(), assetId)
+}
+
+func (s *AssetsService) GetSingleCMAContext(ctx context.Context, assetId string) (*Asset, error) {
+	path := fmt.Sprintf(pathAsset, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
 func (s *AssetsService) Create(body []byte) ([]byte, error) {
+	return s.CreateContext(context.Background(), body)
+}
+
+func (s *AssetsService) CreateContext(ctx context.Context, body []byte) ([]byte, error) {
 	path := fmt.Sprintf(pathAssets, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
-	// Set header for content type
-	s.client.headers[headerContentType] = "application/vnd.contentful.management.v1+json"
-	return s.client.post(path, bytes.NewBuffer(body))
+	return s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+}
+
+// CreateAsset creates an asset with a generated id
+func (s *AssetsService) CreateAsset(fields *AssetFields) (*Asset, error) {
+	return s.CreateAssetContext(context.Background(), fields)
+}
+
+func (s *AssetsService) CreateAssetContext(ctx context.Context, fields *AssetFields) (*Asset, error) {
+	body, err := json.Marshal(&Asset{Fields: fields})
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.CreateContext(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+// Update creates or updates the asset with the given id, version is 0 for new assets
+func (s *AssetsService) Update(version string, assetId string, fields *AssetFields) (*Asset, error) {
+	return s.UpdateContext(context.Background(), version, assetId, fields)
+}
+
+func (s *AssetsService) UpdateContext(ctx context.Context, version string, assetId string, fields *AssetFields) (*Asset, error) {
+	body, err := json.Marshal(&Asset{Fields: fields})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathAsset, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+// Process starts processing the file of a locale at the current version of the asset,
+// the url appears in the file once it is done
+func (s *AssetsService) Process(assetId string, locale string) ([]byte, error) {
+	return s.ProcessContext(context.Background(), assetId, locale)
+}
+
+func (s *AssetsService) ProcessContext(ctx context.Context, assetId string, locale string) ([]byte, error) {
+	asset, err := s.GetSingleCMAContext(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.processLocales(ctx, assetId, asset.Sys.Version, []string{locale})
+}
+
+// ProcessAsset starts processing the file of a locale at the given version of the asset
+func (s *AssetsService) ProcessAsset(assetId string, locale string, version string) error {
+	return s.ProcessAssetContext(context.Background(), assetId, locale, version)
+}
+
+func (s *AssetsService) ProcessAssetContext(ctx context.Context, assetId string, locale string, version string) error {
+	path := fmt.Sprintf(pathAssetsProcess, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId, locale)
+	_, err := s.client.put(ctx, path, nil, withVersion(version))
+	return err
+}
+
+// processLocales processes the files of the locales starting at version, finished processing
+// bumps the version so it is fetched again on a version mismatch
+func (s *AssetsService) processLocales(ctx context.Context, assetId string, version int, locales []string) error {
+	for _, locale := range locales {
+		err := s.client.modify(ctx, nil, func(ctx context.Context) error {
+			err := s.ProcessAssetContext(ctx, assetId, locale, strconv.Itoa(version))
+			var conflict VersionMismatchError
+			if errors.As(err, &conflict) {
+				if latest, err := s.GetSingleCMAContext(ctx, assetId); err == nil {
+					version = latest.Sys.Version
+				}
+			}
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("process %s: %w", locale, err)
+		}
+	}
+	return nil
+}
+
+func (s *AssetsService) Publish(assetId string, version string) ([]byte, error) {
+	return s.PublishContext(context.Background(), assetId, version)
+}
+
+func (s *AssetsService) PublishContext(ctx context.Context, assetId string, version string) ([]byte, error) {
+	path := fmt.Sprintf(pathAssetsPublished, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	return s.client.put(ctx, path, nil, withVersion(version))
+}
+
+// PublishAsset publishes the asset and returns its published state
+func (s *AssetsService) PublishAsset(assetId string, version string) (*Asset, error) {
+	return s.PublishAssetContext(context.Background(), assetId, version)
 }
 
-func (s *AssetsService) Process(id string, locale string) ([]byte, error) {
-	path := fmt.Sprintf(pathAssetsProcess, s.client.Options.SpaceID, s.client.Options.EnvironmentID, id, locale)
-	return s.client.put(path, nil)
+func (s *AssetsService) PublishAssetContext(ctx context.Context, assetId string, version string) (*Asset, error) {
+	data, err := s.PublishContext(ctx, assetId, version)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+func (s *AssetsService) UnPublish(assetId string, version string) (*Asset, error) {
+	return s.UnPublishContext(context.Background(), assetId, version)
+}
+
+func (s *AssetsService) UnPublishContext(ctx context.Context, assetId string, version string) (*Asset, error) {
+	path := fmt.Sprintf(pathAssetsPublished, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	data, err := s.client.delete(ctx, path, withVersion(version))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+func (s *AssetsService) Archive(assetId string, version string) (*Asset, error) {
+	return s.ArchiveContext(context.Background(), assetId, version)
+}
+
+func (s *AssetsService) ArchiveContext(ctx context.Context, assetId string, version string) (*Asset, error) {
+	path := fmt.Sprintf(pathAssetsArchived, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	data, err := s.client.put(ctx, path, nil, withVersion(version))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+func (s *AssetsService) UnArchive(assetId string, version string) (*Asset, error) {
+	return s.UnArchiveContext(context.Background(), assetId, version)
+}
+
+func (s *AssetsService) UnArchiveContext(ctx context.Context, assetId string, version string) (*Asset, error) {
+	path := fmt.Sprintf(pathAssetsArchived, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	data, err := s.client.delete(ctx, path, withVersion(version))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalAsset(data)
+}
+
+// Delete deletes an unpublished asset
+func (s *AssetsService) Delete(assetId string, version string) error {
+	return s.DeleteContext(context.Background(), assetId, version)
 }
 
-func (s *AssetsService) Publish(id string, version string) ([]byte, error) {
-	path := fmt.Sprintf(pathAssetsPublished, s.client.Options.SpaceID, s.client.Options.EnvironmentID, id)
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.put(path, nil)
+func (s *AssetsService) DeleteContext(ctx context.Context, assetId string, version string) error {
+	path := fmt.Sprintf(pathAsset, s.client.Options.SpaceID, s.client.Options.EnvironmentID, assetId)
+	_, err := s.client.delete(ctx, path, withVersion(version))
+	return err
+}
+
+func unmarshalAsset(data []byte) (*Asset, error) {
+	res := &Asset{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func unmarshalAssets(data []byte) (*Assets, error) {
+	res := &Assets{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
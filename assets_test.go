@@ -0,0 +1,143 @@
+package gontentful_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestCreateProcessPublishAsset(t *testing.T) {
+	srv, client := newFake(t)
+
+	upload, err := client.Uploads.CreateUpload(strings.NewReader("pdf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset, err := client.Assets.CreateAsset(&gontentful.AssetFields{
+		Title: map[string]string{gontentful.DefaultLocale: "Terms"},
+		File: map[string]*gontentful.AssetFile{
+			gontentful.DefaultLocale: {FileName: "terms.pdf", ContentType: "application/pdf", UploadFrom: upload.Link()},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Assets.Process(asset.Sys.ID, gontentful.DefaultLocale); err != nil {
+		t.Fatal(err)
+	}
+	processed, err := client.Assets.GetSingleCMA(asset.Sys.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, err := client.Assets.PublishAsset(asset.Sys.ID, strconv.Itoa(processed.Sys.Version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.Fields.File[gontentful.DefaultLocale].URL == "" {
+		t.Error("published asset has no file url")
+	}
+	if a := srv.Asset(asset.Sys.ID); a.Sys.PublishedVersion != processed.Sys.Version {
+		t.Errorf("published version %d, want %d", a.Sys.PublishedVersion, processed.Sys.Version)
+	}
+}
+
+func TestAssetLifecycle(t *testing.T) {
+	srv, client := newFake(t)
+
+	asset, err := client.Assets.CreateAsset(&gontentful.AssetFields{Title: map[string]string{gontentful.DefaultLocale: "Logo"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := asset.Sys.ID
+	asset, err = client.Assets.Update(strconv.Itoa(asset.Sys.Version), id, &gontentful.AssetFields{Title: map[string]string{gontentful.DefaultLocale: "New logo"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Fields.Title[gontentful.DefaultLocale] != "New logo" {
+		t.Errorf("got title %v", asset.Fields.Title)
+	}
+	if _, err := client.Assets.Update(strconv.Itoa(asset.Sys.Version-1), id, asset.Fields); err == nil {
+		t.Error("expected a version mismatch for a stale version")
+	}
+
+	steps := []struct {
+		name string
+		run  func(version string) (*gontentful.Asset, error)
+	}{
+		{"publish", func(v string) (*gontentful.Asset, error) { return client.Assets.PublishAsset(id, v) }},
+		{"unpublish", func(v string) (*gontentful.Asset, error) { return client.Assets.UnPublish(id, v) }},
+		{"archive", func(v string) (*gontentful.Asset, error) { return client.Assets.Archive(id, v) }},
+		{"unarchive", func(v string) (*gontentful.Asset, error) { return client.Assets.UnArchive(id, v) }},
+	}
+	for _, step := range steps {
+		if asset, err = step.run(strconv.Itoa(asset.Sys.Version)); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if step.name == "publish" && srv.Asset(id).Sys.PublishedVersion == 0 {
+			t.Error("asset is not published")
+		}
+	}
+	if err := client.Assets.Delete(id, strconv.Itoa(asset.Sys.Version)); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Asset(id) != nil {
+		t.Error("asset is not deleted")
+	}
+}
+
+func TestAssetUnmarshalJSON(t *testing.T) {
+	// the delivery api returns the fields of the requested locale only
+	delivery := &gontentful.Asset{}
+	err := json.Unmarshal([]byte(`{"sys":{"id":"a","locale":"de"},"fields":{"title":"Logo","file":{"fileName":"logo.png","url":"//x/logo.png"}}}`), delivery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Fields.Title["de"] != "Logo" || delivery.Fields.File["de"] == nil || delivery.Fields.File["de"].URL != "//x/logo.png" {
+		t.Errorf("got fields %+v", delivery.Fields)
+	}
+	if delivery.Fields.Description != nil {
+		t.Errorf("got description %v, want none", delivery.Fields.Description)
+	}
+
+	management := &gontentful.Asset{}
+	err = json.Unmarshal([]byte(`{"sys":{"id":"a"},"fields":{"title":{"en":"Logo","de":"Logo DE"}}}`), management)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(management.Fields.Title) != 2 || management.Fields.Title["de"] != "Logo DE" {
+		t.Errorf("got title %v", management.Fields.Title)
+	}
+
+	empty := &gontentful.Asset{Fields: &gontentful.AssetFields{}}
+	if err := json.Unmarshal([]byte(`{"sys":{"id":"a"}}`), empty); err != nil || empty.Fields != nil {
+		t.Errorf("got fields %+v err %v, want none", empty.Fields, err)
+	}
+}
+
+func TestProcessAssetNeedsVersion(t *testing.T) {
+	_, client := newFake(t)
+
+	upload, err := client.Uploads.CreateUpload(strings.NewReader("pdf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset, err := client.Assets.CreateAsset(&gontentful.AssetFields{
+		File: map[string]*gontentful.AssetFile{
+			gontentful.DefaultLocale: {FileName: "terms.pdf", ContentType: "application/pdf", UploadFrom: upload.Link()},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var conflict gontentful.VersionMismatchError
+	if err := client.Assets.ProcessAsset(asset.Sys.ID, gontentful.DefaultLocale, ""); !errors.As(err, &conflict) {
+		t.Fatalf("got %v, want a version mismatch without a version", err)
+	}
+	if err := client.Assets.ProcessAsset(asset.Sys.ID, gontentful.DefaultLocale, strconv.Itoa(asset.Sys.Version)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,130 @@
+package gontentful
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultProcessTimeout = time.Minute
+)
+
+// AssetUpload describes the asset created by UploadAndPublish
+type AssetUpload struct {
+	// ID of the asset, a generated id is used when empty
+	ID          string
+	Title       map[string]string
+	Description map[string]string
+	FileName    string
+	ContentType string
+	// Locales the file is attached to, the default locale of the space when empty
+	Locales []string
+	// Timeout bounds the wait for processing, 1 minute by default
+	Timeout time.Duration
+}
+
+// UploadAndPublish streams data to the upload api, creates the asset with the
+// file in every locale, processes it, waits until the urls appear and publishes it
+func (s *AssetsService) UploadAndPublish(upload *AssetUpload, data io.Reader) (*Asset, error) {
+	return s.UploadAndPublishContext(context.Background(), upload, data)
+}
+
+func (s *AssetsService) UploadAndPublishContext(ctx context.Context, upload *AssetUpload, data io.Reader) (*Asset, error) {
+	locales := upload.Locales
+	if len(locales) == 0 {
+		code, err := s.defaultLocale(ctx)
+		if err != nil {
+			return nil, err
+		}
+		locales = []string{code}
+	}
+
+	u, err := s.client.Uploads.CreateUploadContext(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %w", upload.FileName, err)
+	}
+
+	fields := &AssetFields{
+		Title:       upload.Title,
+		Description: upload.Description,
+		File:        make(map[string]*AssetFile, len(locales)),
+	}
+	for _, code := range locales {
+		fields.File[code] = &AssetFile{
+			FileName:    upload.FileName,
+			ContentType: upload.ContentType,
+			UploadFrom:  u.Link(),
+		}
+	}
+
+	var asset *Asset
+	if upload.ID == "" {
+		asset, err = s.CreateAssetContext(ctx, fields)
+	} else {
+		asset, err = s.UpdateContext(ctx, "0", upload.ID, fields)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create asset %s: %w", upload.ID, err)
+	}
+
+	if err := s.processLocales(ctx, asset.Sys.ID, asset.Sys.Version, locales); err != nil {
+		return nil, fmt.Errorf("failed to process asset %s: %w", asset.Sys.ID, err)
+	}
+
+	timeout := upload.Timeout
+	if timeout <= 0 {
+		timeout = defaultProcessTimeout
+	}
+	asset, err = s.waitProcessed(ctx, asset.Sys.ID, locales, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.PublishAssetContext(ctx, asset.Sys.ID, strconv.Itoa(asset.Sys.Version))
+}
+
+// waitProcessed polls the asset until every locale has a file url
+func (s *AssetsService) waitProcessed(ctx context.Context, id string, locales []string, timeout time.Duration) (*Asset, error) {
+	var asset *Asset
+	err := poll(ctx, timeout, func(ctx context.Context) (bool, error) {
+		var err error
+		asset, err = s.GetSingleCMAContext(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		return processed(asset, locales), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("asset %s was not processed: %w", id, err)
+	}
+	return asset, nil
+}
+
+func processed(asset *Asset, locales []string) bool {
+	if asset.Fields == nil {
+		return false
+	}
+	for _, code := range locales {
+		file := asset.Fields.File[code]
+		if file == nil || file.URL == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *AssetsService) defaultLocale(ctx context.Context) (string, error) {
+	locales, err := s.client.Locales.GetCMALocalesContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, l := range locales.Items {
+		if l.Default {
+			return l.Code, nil
+		}
+	}
+	return DefaultLocale, nil
+}
@@ -0,0 +1,70 @@
+package gontentful_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestUploadAndPublish(t *testing.T) {
+	srv, client := newFake(t)
+	srv.ProcessingDelay = 20 * time.Millisecond
+
+	asset, err := client.Assets.UploadAndPublish(&gontentful.AssetUpload{
+		Title:       map[string]string{gontentful.DefaultLocale: "Logo"},
+		FileName:    "logo.png",
+		ContentType: "image/png",
+	}, strings.NewReader("png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := asset.Fields.File[gontentful.DefaultLocale]
+	if file == nil || file.URL == "" || file.Details.Size != 3 {
+		t.Fatalf("got file %+v, want a processed file", file)
+	}
+	if a := srv.Asset(asset.Sys.ID); a == nil || a.Sys.PublishedVersion == 0 {
+		t.Fatalf("asset %s is not published", asset.Sys.ID)
+	}
+}
+
+func TestUploadAndPublishLocales(t *testing.T) {
+	srv, client := newFake(t)
+	srv.SetLocales(
+		&gontentful.Locale{Code: "en", Name: "English", Default: true},
+		&gontentful.Locale{Code: "de", Name: "German", FallbackCode: "en"},
+	)
+
+	// processing the first locale finishes at once and bumps the version the second one is processed at
+	asset, err := client.Assets.UploadAndPublish(&gontentful.AssetUpload{
+		Title:       map[string]string{"en": "Logo"},
+		FileName:    "logo.png",
+		ContentType: "image/png",
+		Locales:     []string{"en", "de"},
+	}, strings.NewReader("png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range []string{"en", "de"} {
+		if f := asset.Fields.File[code]; f == nil || f.URL == "" {
+			t.Errorf("file of %s is not processed", code)
+		}
+	}
+}
+
+func TestUploadAndPublishDefaultLocale(t *testing.T) {
+	srv, client := newFake(t)
+	srv.SetLocales(&gontentful.Locale{Code: "de", Name: "German", Default: true})
+
+	asset, err := client.Assets.UploadAndPublish(&gontentful.AssetUpload{FileName: "logo.png", ContentType: "image/png"}, strings.NewReader("png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Fields.File["de"] == nil {
+		t.Errorf("got files %v, want the default locale", asset.Fields.File)
+	}
+	if countRequests(srv, "GET /cda/") != 0 {
+		t.Errorf("read the delivery api: %v", srv.Requests())
+	}
+}
@@ -0,0 +1,288 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	BULK_ACTION = "BulkAction"
+
+	BulkActionPublish   = "publish"
+	BulkActionUnpublish = "unpublish"
+	BulkActionValidate  = "validate"
+
+	BulkActionStatusCreated    = "created"
+	BulkActionStatusInProgress = "inProgress"
+	BulkActionStatusSucceeded  = "succeeded"
+	BulkActionStatusFailed     = "failed"
+
+	// MaxBulkItems is the most entities a single bulk action accepts
+	MaxBulkItems = 200
+
+	defaultBulkTimeout = 5 * time.Minute
+)
+
+// BulkItem is an entry or asset of a bulk action, publishing requires the version to publish
+type BulkItem struct {
+	LinkType string
+	ID       string
+	Version  int
+}
+
+type BulkActionSys struct {
+	Sys
+	Status string `json:"status,omitempty"`
+}
+
+type BulkActionEntities struct {
+	Sys   *Sys     `json:"sys"`
+	Items []*Entry `json:"items"`
+}
+
+type BulkActionPayload struct {
+	Action   string              `json:"action,omitempty"`
+	Entities *BulkActionEntities `json:"entities"`
+}
+
+type BulkAction struct {
+	Sys     *BulkActionSys     `json:"sys"`
+	Action  string             `json:"action"`
+	Payload *BulkActionPayload `json:"payload,omitempty"`
+	Error   *ActionError       `json:"error,omitempty"`
+}
+
+// BulkEntityError is the error of one entity of a failed bulk action
+type BulkEntityError struct {
+	Error  *ActionError `json:"error"`
+	Entity *Entry       `json:"entity"`
+}
+
+// Status returns created, inProgress, succeeded or failed
+func (a *BulkAction) Status() string {
+	if a.Sys == nil {
+		return ""
+	}
+	return a.Sys.Status
+}
+
+// EntityErrors returns the per entity errors of a failed action
+func (a *BulkAction) EntityErrors() []*BulkEntityError {
+	if a.Error == nil || len(a.Error.Details) == 0 {
+		return nil
+	}
+	details := struct {
+		Errors []*BulkEntityError `json:"errors"`
+	}{}
+	if err := json.Unmarshal(a.Error.Details, &details); err != nil {
+		return nil
+	}
+	return details.Errors
+}
+
+// BulkItemResult is the outcome of one item, Error is set when it failed
+type BulkItemResult struct {
+	BulkItem
+	ActionID string
+	Status   string
+	Error    *ActionError
+}
+
+// BulkReport holds the actions run in batches of MaxBulkItems and the result of every item in order
+type BulkReport struct {
+	Action  string
+	Actions []*BulkAction
+	Items   []*BulkItemResult
+}
+
+// Failed returns the results of the failed items
+func (r *BulkReport) Failed() []*BulkItemResult {
+	res := make([]*BulkItemResult, 0)
+	for _, item := range r.Items {
+		if item.Status == BulkActionStatusFailed {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// BulkActionsService publishes, unpublishes and validates many entries and assets per request on the CMA
+type BulkActionsService service
+
+// Publish starts publishing the items at their versions, at most MaxBulkItems
+func (s *BulkActionsService) Publish(items []BulkItem) (*BulkAction, error) {
+	return s.PublishContext(context.Background(), items)
+}
+
+func (s *BulkActionsService) PublishContext(ctx context.Context, items []BulkItem) (*BulkAction, error) {
+	return s.create(ctx, BulkActionPublish, items)
+}
+
+// UnPublish starts unpublishing the items, at most MaxBulkItems
+func (s *BulkActionsService) UnPublish(items []BulkItem) (*BulkAction, error) {
+	return s.UnPublishContext(context.Background(), items)
+}
+
+func (s *BulkActionsService) UnPublishContext(ctx context.Context, items []BulkItem) (*BulkAction, error) {
+	return s.create(ctx, BulkActionUnpublish, items)
+}
+
+// Validate starts validating the items for publishing, at most MaxBulkItems
+func (s *BulkActionsService) Validate(items []BulkItem) (*BulkAction, error) {
+	return s.ValidateContext(context.Background(), items)
+}
+
+func (s *BulkActionsService) ValidateContext(ctx context.Context, items []BulkItem) (*BulkAction, error) {
+	return s.create(ctx, BulkActionValidate, items)
+}
+
+func (s *BulkActionsService) GetAction(bulkActionId string) (*BulkAction, error) {
+	return s.GetActionContext(context.Background(), bulkActionId)
+}
+
+func (s *BulkActionsService) GetActionContext(ctx context.Context, bulkActionId string) (*BulkAction, error) {
+	path := fmt.Sprintf(pathBulkAction, s.client.Options.SpaceID, s.client.Options.EnvironmentID, bulkActionId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBulkAction(data)
+}
+
+// WaitAction polls the action until it succeeds or fails
+func (s *BulkActionsService) WaitAction(action *BulkAction, timeout time.Duration) (*BulkAction, error) {
+	return s.WaitActionContext(context.Background(), action, timeout)
+}
+
+func (s *BulkActionsService) WaitActionContext(ctx context.Context, action *BulkAction, timeout time.Duration) (*BulkAction, error) {
+	if action == nil || action.Sys == nil {
+		return nil, fmt.Errorf("invalid bulk action")
+	}
+	if timeout <= 0 {
+		timeout = defaultBulkTimeout
+	}
+	res := action
+	err := poll(ctx, timeout, func(ctx context.Context) (bool, error) {
+		if bulkActionDone(res) {
+			return true, nil
+		}
+		var err error
+		res, err = s.GetActionContext(ctx, action.Sys.ID)
+		if err != nil {
+			return false, err
+		}
+		return bulkActionDone(res), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bulk action %s did not finish: %w", action.Sys.ID, err)
+	}
+	return res, nil
+}
+
+// Run runs the action on any number of items in batches of MaxBulkItems, one batch at a time,
+// and reports the result of every item. Items failing do not stop the run, an error is only
+// returned when a batch could not be submitted or did not finish within the timeout.
+func (s *BulkActionsService) Run(action string, items []BulkItem, timeout time.Duration) (*BulkReport, error) {
+	return s.RunContext(context.Background(), action, items, timeout)
+}
+
+func (s *BulkActionsService) RunContext(ctx context.Context, action string, items []BulkItem, timeout time.Duration) (*BulkReport, error) {
+	report := &BulkReport{
+		Action:  action,
+		Actions: make([]*BulkAction, 0),
+		Items:   make([]*BulkItemResult, 0, len(items)),
+	}
+	for start := 0; start < len(items); start += MaxBulkItems {
+		end := start + MaxBulkItems
+		if end > len(items) {
+			end = len(items)
+		}
+		batch := items[start:end]
+		a, err := s.create(ctx, action, batch)
+		if err != nil {
+			return report, err
+		}
+		a, err = s.WaitActionContext(ctx, a, timeout)
+		if err != nil {
+			return report, err
+		}
+		report.Actions = append(report.Actions, a)
+		report.Items = append(report.Items, bulkItemResults(a, batch)...)
+	}
+	return report, nil
+}
+
+func (s *BulkActionsService) create(ctx context.Context, action string, items []BulkItem) (*BulkAction, error) {
+	if len(items) > MaxBulkItems {
+		return nil, fmt.Errorf("bulk action of %d items, at most %d are allowed", len(items), MaxBulkItems)
+	}
+	payload := &BulkActionPayload{
+		Entities: &BulkActionEntities{
+			Sys:   &Sys{Type: "Array"},
+			Items: make([]*Entry, 0, len(items)),
+		},
+	}
+	if action == BulkActionValidate {
+		payload.Action = BulkActionPublish
+	}
+	for _, item := range items {
+		link := Link{LinkType: item.LinkType, ID: item.ID}.entry()
+		if action == BulkActionPublish {
+			link.Sys.Version = item.Version
+		}
+		payload.Entities.Items = append(payload.Entities.Items, link)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathBulkActions, s.client.Options.SpaceID, s.client.Options.EnvironmentID, action)
+	data, err := s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBulkAction(data)
+}
+
+func bulkActionDone(a *BulkAction) bool {
+	return a.Status() == BulkActionStatusSucceeded || a.Status() == BulkActionStatusFailed
+}
+
+// bulkItemResults maps the entity errors of the action to its items, a failed action applies
+// none of its items so those without an error of their own fail with the action error
+func bulkItemResults(a *BulkAction, items []BulkItem) []*BulkItemResult {
+	errs := make(map[string]*ActionError)
+	for _, e := range a.EntityErrors() {
+		if e.Entity != nil && e.Entity.Sys != nil {
+			errs[e.Entity.Sys.LinkType+e.Entity.Sys.ID] = e.Error
+		}
+	}
+	res := make([]*BulkItemResult, 0, len(items))
+	for _, item := range items {
+		r := &BulkItemResult{
+			BulkItem: item,
+			ActionID: a.Sys.ID,
+			Status:   a.Status(),
+		}
+		if a.Status() == BulkActionStatusFailed {
+			r.Error = a.Error
+			if err, ok := errs[item.LinkType+item.ID]; ok {
+				r.Error = err
+			}
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func unmarshalBulkAction(data []byte) (*BulkAction, error) {
+	res := &BulkAction{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,96 @@
+package gontentful_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+	"github.com/moonwalker/gontentful/gontentfultest"
+)
+
+// bulkItems returns the entries at their current versions, so publishing them succeeds
+func bulkItems(srv *gontentfultest.Server, n int) []gontentful.BulkItem {
+	items := make([]gontentful.BulkItem, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("e%03d", i)
+		srv.AddEntry(entry(id, "page", gontentful.Fields{"title": localized(id)}))
+		items = append(items, gontentful.BulkItem{LinkType: gontentful.ENTRY, ID: id, Version: srv.Entry(id).Sys.Version})
+	}
+	return items
+}
+
+func TestBulkRunBatches(t *testing.T) {
+	for _, tc := range []struct {
+		items   int
+		batches []int
+	}{
+		{200, []int{200}},
+		{201, []int{200, 1}},
+		{401, []int{200, 200, 1}},
+	} {
+		srv, client := newFake(t)
+		items := bulkItems(srv, tc.items)
+
+		report, err := client.BulkActions.Run(gontentful.BulkActionPublish, items, time.Second)
+		if err != nil {
+			t.Fatalf("%d items: %v", tc.items, err)
+		}
+		if len(report.Actions) != len(tc.batches) {
+			t.Fatalf("%d items: got %d actions, want %d", tc.items, len(report.Actions), len(tc.batches))
+		}
+		for i, a := range report.Actions {
+			if n := len(a.Payload.Entities.Items); n != tc.batches[i] {
+				t.Errorf("%d items: batch %d has %d items, want %d", tc.items, i, n, tc.batches[i])
+			}
+		}
+		if n := countRequests(srv, "POST /cma/spaces/space/environments/master/bulk_actions/publish"); n != len(tc.batches) {
+			t.Errorf("%d items: got %d bulk actions created", tc.items, n)
+		}
+		if len(report.Items) != len(items) || len(report.Failed()) != 0 {
+			t.Fatalf("%d items: got %d results, %d failed", tc.items, len(report.Items), len(report.Failed()))
+		}
+		for i, r := range report.Items {
+			if r.ID != items[i].ID || r.ActionID != report.Actions[i/gontentful.MaxBulkItems].Sys.ID {
+				t.Errorf("%d items: result %d is %s of action %s", tc.items, i, r.ID, r.ActionID)
+			}
+		}
+		if e := srv.Entry(items[len(items)-1].ID); e.Sys.PublishedCounter != 2 {
+			t.Errorf("%d items: the last item was published %d times, want 2", tc.items, e.Sys.PublishedCounter)
+		}
+	}
+}
+
+func TestBulkRunFailedBatch(t *testing.T) {
+	srv, client := newFake(t)
+	items := bulkItems(srv, 250)
+	items[10].Version--
+
+	report, err := client.BulkActions.Run(gontentful.BulkActionPublish, items, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Actions) != 2 {
+		t.Fatalf("got %d actions, want 2", len(report.Actions))
+	}
+	if report.Actions[0].Status() != gontentful.BulkActionStatusFailed || report.Actions[1].Status() != gontentful.BulkActionStatusSucceeded {
+		t.Errorf("got %s and %s", report.Actions[0].Status(), report.Actions[1].Status())
+	}
+
+	// a failed batch applies none of its items, the items without an error of their own get the action error
+	if failed := report.Failed(); len(failed) != gontentful.MaxBulkItems {
+		t.Fatalf("got %d failed items, want the first batch", len(failed))
+	}
+	if err := report.Items[10].Error; err == nil || err.Sys.ID != "VersionMismatch" {
+		t.Errorf("got %v for the stale item", err)
+	}
+	if err := report.Items[11].Error; err != report.Actions[0].Error {
+		t.Errorf("got %v for an item of the failed batch", err)
+	}
+	if e := srv.Entry(items[11].ID); e.Sys.PublishedCounter != 1 {
+		t.Error("an item of the failed batch was published")
+	}
+	if e := srv.Entry(items[200].ID); e.Sys.PublishedCounter != 2 {
+		t.Error("an item of the second batch was not published")
+	}
+}
@@ -0,0 +1,558 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	CloneUnchanged   = "unchanged"
+	CloneConflict    = "conflict"
+	CloneUnpublished = "unpublished"
+)
+
+// CloneOptions select the published content copied by CloneTo. Entries matching every given filter
+// are copied with the entries and assets they link to, without filters everything is copied.
+type CloneOptions struct {
+	ContentTypes []string
+	// Tags selects the entries tagged with any of the tags
+	Tags []string
+	// Query selects the entries returned by the delivery api for it, e.g. fields.slug[in]=a,b
+	Query url.Values
+	// SkipLinked copies the selected entries only, links to content missing from the target are reported
+	SkipLinked bool
+	// SyncToken continues from a previous clone, only content published since is copied and content
+	// unpublished or deleted since is unpublished in the target, whatever the filters
+	SyncToken string
+	// Overwrite overwrites or unpublishes target entries and assets with unpublished changes instead of reporting a conflict
+	Overwrite bool
+	// ProcessTimeout is the wait for asset files to be processed before publishing, a minute by default
+	ProcessTimeout time.Duration
+}
+
+// CloneReport lists the cloned entities like an import, conflicts are left out of Failed
+type CloneReport struct {
+	ImportReport
+	// SyncToken continues the next clone where this one ended, the one it started from when items failed
+	SyncToken string `json:"syncToken"`
+	// MissingLinks are the links of copied entries to content neither copied nor in the target
+	MissingLinks []Link `json:"missingLinks,omitempty"`
+}
+
+// Conflicts returns the items skipped because the target has unpublished changes
+func (r *CloneReport) Conflicts() []*ImportItem {
+	res := make([]*ImportItem, 0)
+	for _, item := range r.Items {
+		if item.Action == CloneConflict {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// CloneTo copies published entries and assets from the space environment of the client to the one of
+// target keeping their ids, with the locales, tags and content types they need. The source is read with
+// the sync api, locales, content types and tags from the management api when the client has a management token.
+// Versions differ between spaces, so target entries and assets are compared by content: those already
+// published with the same content are left unchanged, those with unpublished changes are reported as
+// conflicts unless Overwrite is set. A never published draft with the source content, as left by an
+// interrupted clone, is overwritten.
+func (s *SpacesService) CloneTo(target *Client, opts *CloneOptions) (*CloneReport, error) {
+	return s.CloneToContext(context.Background(), target, opts)
+}
+
+func (s *SpacesService) CloneToContext(ctx context.Context, target *Client, opts *CloneOptions) (*CloneReport, error) {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+	c := &cloner{
+		source:   s.client,
+		opts:     opts,
+		importer: newImporter(target, &ImportOptions{ProcessTimeout: opts.ProcessTimeout}),
+		entries:  make(map[string]*Entry),
+		assets:   make(map[string]*Entry),
+	}
+	report := &CloneReport{}
+
+	token, err := s.SyncPagedContext(ctx, opts.SyncToken, func(res *SyncResponse) {
+		for _, item := range res.Items {
+			if item.Sys == nil {
+				continue
+			}
+			switch item.Sys.Type {
+			case ENTRY:
+				c.entries[item.Sys.ID] = item
+				c.order = append(c.order, item)
+			case ASSET:
+				c.assets[item.Sys.ID] = item
+				c.order = append(c.order, item)
+			case DELETED_ENTRY, DELETED_ASSET:
+				c.deleted = append(c.deleted, item)
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("clone sync: %w", err)
+	}
+	// a failed clone is retried from where it started, the items it did copy are unchanged then
+	report.SyncToken = opts.SyncToken
+
+	entries, assets, err := c.selection(ctx)
+	if err != nil {
+		report.ImportReport = *c.report
+		return report, err
+	}
+
+	steps := []func(context.Context, []*Entry, []*Entry) error{
+		c.locales,
+		c.tags,
+		c.contentTypes,
+		c.writeAssets,
+		c.writeEntries,
+		c.unpublishDeleted,
+	}
+	for _, step := range steps {
+		if err := step(ctx, entries, assets); err != nil {
+			report.ImportReport = *c.report
+			return report, err
+		}
+	}
+	report.MissingLinks, err = c.missingLinks(ctx, entries)
+	report.ImportReport = *c.report
+	if err == nil && len(report.Failed()) == 0 {
+		report.SyncToken = token
+	}
+	return report, err
+}
+
+type cloner struct {
+	*importer
+	source *Client
+	opts   *CloneOptions
+	// entries and assets are the synced items by id, order keeps their sync order
+	entries map[string]*Entry
+	assets  map[string]*Entry
+	order   []*Entry
+	// deleted are the entries and assets unpublished or deleted since the sync token
+	deleted []*Entry
+}
+
+// selection returns the synced entries matching the filters and the entries and assets they link to
+func (c *cloner) selection(ctx context.Context) ([]*Entry, []*Entry, error) {
+	var queried map[string]bool
+	if c.opts.Query != nil {
+		queried = make(map[string]bool)
+		query := url.Values{}
+		for k, v := range c.opts.Query {
+			query[k] = v
+		}
+		query.Set("select", "sys.id")
+		err := c.source.Entries.Each(ctx, query, nil, func(e *Entry) error {
+			queried[e.Sys.ID] = true
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("clone query: %w", err)
+		}
+	}
+
+	selected := make(map[Link]bool)
+	pending := make([]Link, 0)
+	for _, item := range c.order {
+		if item.Sys.Type != ENTRY || !c.matches(item, queried) {
+			continue
+		}
+		l := Link{LinkType: ENTRY, ID: item.Sys.ID}
+		selected[l] = true
+		pending = append(pending, l)
+	}
+	if !c.opts.SkipLinked {
+		for len(pending) > 0 {
+			l := pending[0]
+			pending = pending[1:]
+			e := c.entries[l.ID]
+			if l.LinkType != ENTRY || e == nil {
+				continue
+			}
+			for _, linked := range fieldLinks(e.Fields) {
+				if selected[linked] {
+					continue
+				}
+				if (linked.LinkType == ENTRY && c.entries[linked.ID] != nil) || (linked.LinkType == ASSET && c.assets[linked.ID] != nil) {
+					selected[linked] = true
+					pending = append(pending, linked)
+				}
+			}
+		}
+	}
+
+	entries := make([]*Entry, 0)
+	assets := make([]*Entry, 0)
+	for _, item := range c.order {
+		if !selected[Link{LinkType: item.Sys.Type, ID: item.Sys.ID}] {
+			continue
+		}
+		if item.Sys.Type == ENTRY {
+			entries = append(entries, item)
+		} else {
+			assets = append(assets, item)
+		}
+	}
+	return entries, assets, nil
+}
+
+func (c *cloner) matches(e *Entry, queried map[string]bool) bool {
+	if len(c.opts.ContentTypes) > 0 && !containsString(c.opts.ContentTypes, entryContentType(e)) {
+		return false
+	}
+	if len(c.opts.Tags) > 0 {
+		tagged := false
+		for _, id := range e.Metadata.TagIDs() {
+			if containsString(c.opts.Tags, id) {
+				tagged = true
+				break
+			}
+		}
+		if !tagged {
+			return false
+		}
+	}
+	return queried == nil || queried[e.Sys.ID]
+}
+
+// locales creates the source locales missing in the target, with their optional and api flags
+// when the source is read from the management api
+func (c *cloner) locales(ctx context.Context, _ []*Entry, _ []*Entry) error {
+	fetch := c.source.Locales.GetLocalesContext
+	if c.source.Options.CmaToken != "" {
+		fetch = c.source.Locales.GetCMALocalesContext
+	}
+	locales, err := fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("clone locales: %w", err)
+	}
+	return c.importer.locales(ctx, &SpaceExport{Locales: locales.Items})
+}
+
+// tags creates the tags of the copied content missing in the target
+func (c *cloner) tags(ctx context.Context, entries []*Entry, assets []*Entry) error {
+	used := make(map[string]bool)
+	for _, items := range [][]*Entry{entries, assets} {
+		for _, item := range items {
+			for _, id := range item.Metadata.TagIDs() {
+				used[id] = true
+			}
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+	fetch := c.source.Tags.GetTagsContext
+	if c.source.Options.CmaToken != "" {
+		fetch = c.source.Tags.GetCMATagsContext
+	}
+	export := &SpaceExport{Tags: make([]*Tag, 0, len(used))}
+	err := paginate(ctx, nil, &PageOptions{Limit: exportPageLimit}, fetch, func(p *Tags) int { return p.Total }, func(p *Tags) error {
+		for _, t := range p.Items {
+			if t.Sys != nil && used[t.Sys.ID] {
+				export.Tags = append(export.Tags, t)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("clone tags: %w", err)
+	}
+	return c.importer.tags(ctx, export)
+}
+
+// contentTypes creates the content types of the copied entries missing in the target,
+// existing ones are left as they are
+func (c *cloner) contentTypes(ctx context.Context, entries []*Entry, _ []*Entry) error {
+	ids := make([]string, 0)
+	for _, e := range entries {
+		if id := entryContentType(e); id != "" && !containsString(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	export := &SpaceExport{ContentTypes: make([]json.RawMessage, 0)}
+	for _, id := range ids {
+		path := fmt.Sprintf(pathContentType, c.client.Options.SpaceID, c.client.Options.EnvironmentID, id)
+		_, err := c.client.getCMA(ctx, path, nil)
+		var notFound NotFoundError
+		if err == nil {
+			item := c.report.add(CONTENT_TYPE, id)
+			item.ID = id
+			item.Action = ImportSkipped
+			continue
+		}
+		if !errors.As(err, &notFound) {
+			return fmt.Errorf("clone content type %s: %w", id, err)
+		}
+		var raw []byte
+		if c.source.Options.CmaToken != "" {
+			path = fmt.Sprintf(pathContentType, c.source.Options.SpaceID, c.source.Options.EnvironmentID, id)
+			raw, err = c.source.getCMA(ctx, path, nil)
+		} else {
+			raw, err = c.source.ContentTypes.GetSingleContext(ctx, id)
+		}
+		if err != nil {
+			return fmt.Errorf("clone content type %s: %w", id, err)
+		}
+		export.ContentTypes = append(export.ContentTypes, raw)
+	}
+	return c.importer.contentTypes(ctx, export)
+}
+
+// writeAssets writes the changed assets, processes and publishes them
+func (c *cloner) writeAssets(ctx context.Context, _ []*Entry, assets []*Entry) error {
+	for _, a := range assets {
+		item := c.report.add(ASSET, a.Sys.ID)
+		item.ID = a.Sys.ID
+		src := &AssetFields{}
+		if err := remarshal(a.Fields, src); err != nil {
+			item.fail(err)
+			continue
+		}
+		fields, locales := importAssetFields(src)
+		body, err := json.Marshal(&Asset{Metadata: a.Metadata, Fields: fields})
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		path := fmt.Sprintf(pathAsset, c.client.Options.SpaceID, c.client.Options.EnvironmentID, a.Sys.ID)
+		same := func(current []byte) bool {
+			cur := &Asset{}
+			return json.Unmarshal(current, cur) == nil && sameAsset(cur, a.Metadata, src)
+		}
+		version, written, err := c.writeTarget(ctx, item, path, body, same, withContentType(mimeManagement))
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		if !written {
+			continue
+		}
+		if err := c.processAsset(ctx, item, locales, version); err != nil {
+			item.fail(err)
+			continue
+		}
+		if err := c.publishAsset(ctx, item, locales, version); err != nil {
+			item.fail(err)
+		}
+	}
+	return nil
+}
+
+// writeEntries writes the changed entries, linked entries first, and publishes them in bulk
+func (c *cloner) writeEntries(ctx context.Context, entries []*Entry, _ []*Entry) error {
+	items := make([]BulkItem, 0)
+	publish := make(map[string]*ImportItem)
+	for _, e := range SortEntriesByLinks(entries) {
+		item := c.report.add(ENTRY, e.Sys.ID)
+		item.ID = e.Sys.ID
+		body, err := json.Marshal(&Entry{Sys: &Sys{}, Metadata: e.Metadata, Fields: e.Fields})
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		path := fmt.Sprintf(pathEntry, c.client.Options.SpaceID, c.client.Options.EnvironmentID, e.Sys.ID)
+		same := func(current []byte) bool {
+			cur := &Entry{}
+			return json.Unmarshal(current, cur) == nil &&
+				equalValues(cur.Metadata.TagIDs(), e.Metadata.TagIDs()) &&
+				equalValues(cur.Fields, e.Fields)
+		}
+		version, written, err := c.writeTarget(ctx, item, path, body, same, withContentfulContentType(entryContentType(e)))
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		if written {
+			items = append(items, BulkItem{LinkType: ENTRY, ID: e.Sys.ID, Version: version})
+			publish[e.Sys.ID] = item
+		}
+	}
+	return c.publishEntries(ctx, items, publish)
+}
+
+// writeTarget creates the entity at path or updates it unless the target is published at its latest
+// version with the same content (unchanged) or has unpublished changes (conflict)
+func (c *cloner) writeTarget(ctx context.Context, item *ImportItem, path string, body []byte, same func(current []byte) bool, opts ...reqOption) (int, bool, error) {
+	current, err := c.client.getCMA(ctx, path, nil)
+	var notFound NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		item.Action = ImportCreated
+	case err != nil:
+		return 0, false, err
+	default:
+		cur := &Entry{}
+		if err := json.Unmarshal(current, cur); err != nil {
+			return 0, false, err
+		}
+		if cur.Sys == nil {
+			return 0, false, errors.New("response without sys")
+		}
+		draft := cur.Sys.PublishedVersion == 0 || cur.Sys.Version > cur.Sys.PublishedVersion+1
+		if !draft && same(current) {
+			item.Action = CloneUnchanged
+			item.Published = true
+			return cur.Sys.Version, false, nil
+		}
+		// a never published draft with the source content is left by an interrupted clone
+		interrupted := cur.Sys.PublishedCounter == 0 && cur.Sys.FirstPublishedAt == "" && same(current)
+		if draft && !interrupted && !c.opts.Overwrite {
+			item.Action = CloneConflict
+			return cur.Sys.Version, false, nil
+		}
+		item.Action = ImportUpdated
+		opts = append(opts, withVersion(strconv.Itoa(cur.Sys.Version)))
+	}
+	data, err := c.client.put(ctx, path, bytes.NewBuffer(body), opts...)
+	if err != nil {
+		return 0, false, err
+	}
+	version, err := sysVersion(data)
+	return version, err == nil, err
+}
+
+// unpublishDeleted unpublishes the target entries and assets unpublished or deleted in the source,
+// those with unpublished changes are reported as conflicts unless Overwrite is set
+func (c *cloner) unpublishDeleted(ctx context.Context, _ []*Entry, _ []*Entry) error {
+	for _, d := range c.deleted {
+		typ, path := ENTRY, pathEntry
+		if d.Sys.Type == DELETED_ASSET {
+			typ, path = ASSET, pathAsset
+		}
+		path = fmt.Sprintf(path, c.client.Options.SpaceID, c.client.Options.EnvironmentID, d.Sys.ID)
+		current, err := c.client.getCMA(ctx, path, nil)
+		var notFound NotFoundError
+		if errors.As(err, &notFound) {
+			continue
+		}
+		item := c.report.add(typ, d.Sys.ID)
+		item.ID = d.Sys.ID
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		cur := &Entry{}
+		if err := json.Unmarshal(current, cur); err != nil {
+			item.fail(err)
+			continue
+		}
+		if cur.Sys == nil {
+			item.fail(errors.New("response without sys"))
+			continue
+		}
+		if cur.Sys.PublishedVersion == 0 {
+			item.Action = ImportSkipped
+			continue
+		}
+		if cur.Sys.Version > cur.Sys.PublishedVersion+1 && !c.opts.Overwrite {
+			item.Action = CloneConflict
+			continue
+		}
+		if _, err := c.client.delete(ctx, path+"/published", withVersion(strconv.Itoa(cur.Sys.Version))); err != nil {
+			item.fail(err)
+			continue
+		}
+		item.Action = CloneUnpublished
+	}
+	return nil
+}
+
+// missingLinks returns the links of the copied entries to entries and assets not copied
+// that the target does not have either
+func (c *cloner) missingLinks(ctx context.Context, entries []*Entry) ([]Link, error) {
+	copied := make(map[Link]bool)
+	for _, item := range c.report.Items {
+		if item.Type == ENTRY || item.Type == ASSET {
+			copied[Link{LinkType: item.Type, ID: item.SourceID}] = true
+		}
+	}
+	res := make([]Link, 0)
+	checked := make(map[Link]bool)
+	for _, e := range entries {
+		for _, l := range fieldLinks(e.Fields) {
+			if copied[l] || checked[l] {
+				continue
+			}
+			checked[l] = true
+			path := fmt.Sprintf(pathEntry, c.client.Options.SpaceID, c.client.Options.EnvironmentID, l.ID)
+			if l.LinkType == ASSET {
+				path = fmt.Sprintf(pathAsset, c.client.Options.SpaceID, c.client.Options.EnvironmentID, l.ID)
+			} else if l.LinkType != ENTRY {
+				continue
+			}
+			_, err := c.client.getCMA(ctx, path, nil)
+			var notFound NotFoundError
+			if errors.As(err, &notFound) {
+				res = append(res, l)
+			} else if err != nil {
+				return res, fmt.Errorf("clone links: %w", err)
+			}
+		}
+	}
+	return res, nil
+}
+
+// sameAsset compares the metadata and fields of the assets without the processed file urls and details
+func sameAsset(cur *Asset, metadata *Metadata, fields *AssetFields) bool {
+	if !equalValues(cur.Metadata.TagIDs(), metadata.TagIDs()) {
+		return false
+	}
+	if cur.Fields == nil {
+		return false
+	}
+	if !equalValues(cur.Fields.Title, fields.Title) || !equalValues(cur.Fields.Description, fields.Description) {
+		return false
+	}
+	if len(cur.Fields.File) != len(fields.File) {
+		return false
+	}
+	for locale, f := range fields.File {
+		cf := cur.Fields.File[locale]
+		if f == nil || cf == nil {
+			if f != cf {
+				return false
+			}
+			continue
+		}
+		if cf.FileName != f.FileName || cf.ContentType != f.ContentType {
+			return false
+		}
+	}
+	return true
+}
+
+func entryContentType(e *Entry) string {
+	if e.Sys == nil || e.Sys.ContentType == nil || e.Sys.ContentType.Sys == nil {
+		return ""
+	}
+	return e.Sys.ContentType.Sys.ID
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func remarshal(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
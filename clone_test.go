@@ -0,0 +1,142 @@
+package gontentful_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+	"github.com/moonwalker/gontentful/gontentfultest"
+)
+
+func newCloneSource(t *testing.T) (*gontentfultest.Server, *gontentful.Client) {
+	t.Helper()
+	srv, client := newFake(t)
+	disabled := false
+	srv.SetLocales(
+		&gontentful.Locale{Code: gontentful.DefaultLocale, Name: "English", Default: true},
+		&gontentful.Locale{Code: "de", Name: "German", FallbackCode: gontentful.DefaultLocale, Optional: true, ContentDeliveryAPI: &disabled},
+	)
+	srv.AddContentType(&gontentful.ContentType{
+		Sys:          &gontentful.Sys{ID: "page"},
+		Name:         "Page",
+		DisplayField: "title",
+		Fields:       []*gontentful.ContentTypeField{{ID: "title", Name: "Title", Type: "Symbol"}},
+	})
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"title": localized("A")}))
+	srv.AddEntry(entry("b", "page", gontentful.Fields{"title": localized("B")}))
+	return srv, client
+}
+
+func cloneActions(report *gontentful.CloneReport, typ string) map[string]string {
+	res := make(map[string]string)
+	for _, item := range report.Items {
+		if item.Type == typ {
+			res[item.SourceID] = item.Action
+		}
+	}
+	return res
+}
+
+func TestClone(t *testing.T) {
+	_, source := newCloneSource(t)
+	target, targetClient := newFake(t)
+
+	report, err := source.Spaces.CloneTo(targetClient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range report.Failed() {
+		t.Errorf("%s %s failed: %s", item.Type, item.SourceID, item.Error)
+	}
+	for _, id := range []string{"a", "b"} {
+		if e := target.Entry(id); e == nil || e.Sys.PublishedVersion == 0 {
+			t.Errorf("entry %s is not published in the target", id)
+		}
+	}
+	locales, err := targetClient.Locales.GetCMALocales()
+	if err != nil {
+		t.Fatal(err)
+	}
+	de := &gontentful.Locale{}
+	for _, l := range locales.Items {
+		if l.Code == "de" {
+			de = l
+		}
+	}
+	if !de.Optional || de.ContentDeliveryAPI == nil || *de.ContentDeliveryAPI {
+		t.Errorf("got locale %+v, want the flags of the source", de)
+	}
+
+	report, err = source.Spaces.CloneTo(targetClient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cloneActions(report, gontentful.ENTRY); got["a"] != gontentful.CloneUnchanged || got["b"] != gontentful.CloneUnchanged {
+		t.Errorf("got actions %v, want unchanged", got)
+	}
+}
+
+func TestCloneDrafts(t *testing.T) {
+	_, source := newCloneSource(t)
+	_, targetClient := newFake(t)
+
+	// a draft with the source content, as left by an interrupted clone, and one with other content
+	export := &gontentful.SpaceExport{Entries: []*gontentful.Entry{
+		entry("a", "page", gontentful.Fields{"title": localized("A")}),
+		entry("b", "page", gontentful.Fields{"title": localized("Changed")}),
+	}}
+	if _, err := targetClient.Spaces.Import(export, &gontentful.ImportOptions{SkipContentModel: true, SkipLocales: true, SkipTags: true, SkipWebhooks: true, SkipContentPublishing: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := source.Spaces.CloneTo(targetClient, &gontentful.CloneOptions{SkipLinked: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := cloneActions(report, gontentful.ENTRY)
+	if got["a"] != gontentful.ImportUpdated || got["b"] != gontentful.CloneConflict {
+		t.Errorf("got actions %v, want a updated and b in conflict", got)
+	}
+}
+
+func TestCloneSyncToken(t *testing.T) {
+	srv, source := newCloneSource(t)
+	target, targetClient := newFake(t)
+
+	report, err := source.Spaces.CloneTo(targetClient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := report.SyncToken
+	if token == "" {
+		t.Fatal("no sync token")
+	}
+
+	a := srv.Entry("a")
+	if _, err := source.Entries.UnPublish("a", strconv.Itoa(a.Sys.Version)); err != nil {
+		t.Fatal(err)
+	}
+	target.Inject(gontentfultest.Failure{Method: http.MethodDelete, Path: "/entries/a/published", Status: http.StatusBadRequest, ErrorID: "BadRequest"})
+	report, err = source.Spaces.CloneTo(targetClient, &gontentful.CloneOptions{SyncToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Failed()) != 1 || report.SyncToken != token {
+		t.Fatalf("got %d failed and token %q, want the failed clone to keep %q", len(report.Failed()), report.SyncToken, token)
+	}
+
+	report, err = source.Spaces.CloneTo(targetClient, &gontentful.CloneOptions{SyncToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cloneActions(report, gontentful.ENTRY); len(got) != 1 || got["a"] != gontentful.CloneUnpublished {
+		t.Errorf("got actions %v, want a unpublished", got)
+	}
+	if e := target.Entry("a"); e.Sys.PublishedVersion != 0 {
+		t.Errorf("entry a is still published in the target")
+	}
+	if report.SyncToken == token || report.SyncToken == "" {
+		t.Errorf("got token %q, want a new one", report.SyncToken)
+	}
+}
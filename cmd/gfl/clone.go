@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/moonwalker/gontentful"
+)
+
+var (
+	cloneTargetSpace       string
+	cloneTargetEnvironment string
+	cloneTargetToken       string
+	cloneQuery             string
+	cloneReport            string
+	cloneOptions           gontentful.CloneOptions
+)
+
+func init() {
+	cloneCmd.Flags().StringVar(&cloneTargetSpace, "target-space", "", "target space id, the source space by default")
+	cloneCmd.Flags().StringVar(&cloneTargetEnvironment, "target-environment", "", "target environment (required)")
+	cloneCmd.Flags().StringVar(&cloneTargetToken, "target-cma", "", "target cma token, the source cma token by default")
+	cloneCmd.Flags().StringSliceVar(&cloneOptions.ContentTypes, "content-type", nil, "clone entries of these content types")
+	cloneCmd.Flags().StringSliceVar(&cloneOptions.Tags, "tag", nil, "clone entries with any of these tags")
+	cloneCmd.Flags().StringVarP(&cloneQuery, "query", "q", "", "clone entries matching this delivery api query, e.g. content_type=game&fields.slug=a")
+	cloneCmd.Flags().BoolVar(&cloneOptions.SkipLinked, "skip-linked", false, "do not clone linked entries and assets")
+	cloneCmd.Flags().StringVar(&cloneOptions.SyncToken, "sync-token", "", "clone content published since a previous clone")
+	cloneCmd.Flags().BoolVar(&cloneOptions.Overwrite, "overwrite", false, "overwrite target changes")
+	cloneCmd.Flags().StringVarP(&cloneReport, "report", "r", "", "write the clone report to this file")
+	cloneCmd.MarkFlagRequired("target-environment")
+	rootCmd.AddCommand(cloneCmd)
+}
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Clone published content to another space or environment",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if cloneQuery != "" {
+			query, err := url.ParseQuery(cloneQuery)
+			if err != nil {
+				log.Fatal(err)
+			}
+			cloneOptions.Query = query
+		}
+		if cloneTargetSpace == "" {
+			cloneTargetSpace = spaceID
+		}
+		if cloneTargetToken == "" {
+			cloneTargetToken = cmaToken
+		}
+
+		source := newClient(&gontentful.ClientOptions{
+			SpaceID:       spaceID,
+			EnvironmentID: environmentID,
+			CdnURL:        apiURL,
+			CdnToken:      cdnToken,
+			CmaURL:        cmaURL,
+			CmaToken:      cmaToken,
+		})
+		target := newClient(&gontentful.ClientOptions{
+			SpaceID:       cloneTargetSpace,
+			EnvironmentID: cloneTargetEnvironment,
+			CmaURL:        cmaURL,
+			CmaToken:      cloneTargetToken,
+		})
+
+		log.Println("clone...")
+		report, err := source.Spaces.CloneTo(target, &cloneOptions)
+		if report != nil && cloneReport != "" {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			if err := os.WriteFile(cloneReport, data, 0644); err != nil {
+				log.Println(err)
+			}
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, item := range report.Failed() {
+			log.Printf("%s %s failed: %s", item.Type, item.SourceID, item.Error)
+		}
+		for _, item := range report.Conflicts() {
+			log.Printf("%s %s has unpublished changes in the target, skipped", item.Type, item.SourceID)
+		}
+		for _, l := range report.MissingLinks {
+			log.Printf("missing %s %s", l.LinkType, l.ID)
+		}
+		log.Printf("cloned %d items, %d failed, %d conflicts, next sync token %s", len(report.Items), len(report.Failed()), len(report.Conflicts()), report.SyncToken)
+	},
+}
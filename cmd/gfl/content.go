@@ -1,16 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gosimple/slug"
@@ -19,13 +18,14 @@ import (
 )
 
 const (
-	queryLimit   = 1000
-	owner        = "moonwalker"
-	branch       = "main"
-	configPath   = "moonbase.yaml"
-	include      = 0
-	outputFormat = "./_output/%s"
-	videoURLHost = "assets.mw.zone"
+	queryLimit      = 1000
+	pageConcurrency = 8
+	owner           = "moonwalker"
+	branch          = "main"
+	configPath      = "moonbase.yaml"
+	include         = 0
+	outputFormat    = "./_output/%s"
+	videoURLHost    = "assets.mw.zone"
 )
 
 type Config struct {
@@ -44,7 +44,7 @@ func transformContent() {
 		CmaToken:      cmaToken,
 		CmaURL:        cmaURL,
 	}
-	cli := gontentful.NewClient(opts)
+	cli := newClient(opts)
 
 	var res *gontentful.Entries
 	var err error
@@ -216,6 +216,9 @@ func transformContent() {
 	}
 
 	fmt.Printf("%d content successfully transformed in %.1fs\n", len(res.Items), time.Since(start).Seconds())
+	if stats := opts.CdnLimiter.Stats(); stats.Waits > 0 {
+		fmt.Printf("%d of %d requests throttled for %.1fs in total\n", stats.Waits, stats.Requests, stats.Waited.Seconds())
+	}
 
 	for _, e := range errors {
 		fmt.Println(e)
@@ -251,49 +254,10 @@ func formatContent() {
 }
 
 func GetContentTypeEntries(cli *gontentful.Client, contenType string) (*gontentful.Entries, error) {
-	var wg sync.WaitGroup
-	var err error
-
-	first, err := cli.Entries.GetEntries(createQuery(contentType, queryLimit, 0))
-	if err != nil {
-		return nil, err
-	}
-
-	res := &gontentful.Entries{
-		Items: first.Items,
-		Limit: first.Limit,
-		Total: first.Total,
-	}
-
-	if first.Total > first.Limit && first.Total > 0 && first.Limit > 0 {
-		rest := int(first.Total / first.Limit)
-		if math.Mod(float64(first.Total), float64(first.Limit)) == 0 {
-			rest = rest - 1
-		}
-		wg.Add(rest)
-		items := make([][]*gontentful.Entry, rest)
-
-		for i := 1; i <= rest; i++ {
-			go func(page int) {
-				defer wg.Done()
-				ctnt, err := cli.Entries.GetEntries(createQuery(contentType, queryLimit, page))
-				if err != nil {
-					return
-				}
-				items[page-1] = ctnt.Items
-			}(i)
-		}
-		wg.Wait()
-		if err != nil {
-			return nil, err
-		}
-		for _, i := range items {
-			res.Items = append(res.Items, i...)
-		}
-		res.Total = int(len(res.Items))
-	}
-
-	return res, nil
+	return cli.Entries.All(context.Background(), createQuery(contenType, queryLimit, 0), &gontentful.PageOptions{
+		Limit:       queryLimit,
+		Concurrency: pageConcurrency,
+	})
 }
 
 func GetAllEntries(cli *gontentful.Client) (*gontentful.Entries, error) {
@@ -311,13 +275,13 @@ func GetAllEntries(cli *gontentful.Client) (*gontentful.Entries, error) {
 }
 
 func createQuery(contentType string, limit int, page int) url.Values {
-	return url.Values{
-		"content_type": []string{contentType},
-		"limit":        []string{fmt.Sprint(limit)},
-		"skip":         []string{fmt.Sprint(limit * page)},
-		"locale":       []string{"*"},
-		"include":      []string{"0"},
-	}
+	return gontentful.NewQuery().
+		ContentType(contentType).
+		Limit(limit).
+		Skip(limit * page).
+		Locale("*").
+		Include(0).
+		Values()
 }
 
 func toCamelCase(s string) string {
@@ -24,7 +24,7 @@ func transformContentType() {
 		CmaToken:      cmaToken,
 		CmaURL:        cmaURL,
 	}
-	cli := gontentful.NewClient(opts)
+	cli := newClient(opts)
 
 	var err error
 	var types *gontentful.ContentTypes
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/moonwalker/gontentful"
+)
+
+var (
+	exportFile    string
+	exportOptions gontentful.ExportOptions
+)
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFile, "file", "f", "contentful-export.json", "export file")
+	exportCmd.Flags().BoolVar(&exportOptions.SkipContentModel, "skip-content-model", false, "skip content types and editor interfaces")
+	exportCmd.Flags().BoolVar(&exportOptions.SkipEditorInterfaces, "skip-editor-interfaces", false, "skip editor interfaces")
+	exportCmd.Flags().BoolVar(&exportOptions.SkipContent, "skip-content", false, "skip entries and assets")
+	exportCmd.Flags().BoolVar(&exportOptions.SkipTags, "skip-tags", false, "skip tags")
+	exportCmd.Flags().BoolVar(&exportOptions.SkipWebhooks, "skip-webhooks", false, "skip webhooks")
+	rootCmd.AddCommand(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a space environment in the contentful-export json format",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient(&gontentful.ClientOptions{
+			SpaceID:       spaceID,
+			EnvironmentID: environmentID,
+			CmaURL:        cmaURL,
+			CmaToken:      cmaToken,
+		})
+
+		log.Println("export...")
+		export, err := client.Spaces.Export(&exportOptions)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		f, err := os.Create(exportFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := export.Write(f); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("exported %d content types, %d entries, %d assets, %d locales, %d tags, %d webhooks to %s",
+			len(export.ContentTypes), len(export.Entries), len(export.Assets), len(export.Locales), len(export.Tags), len(export.Webhooks), exportFile)
+	},
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -11,13 +12,15 @@ import (
 )
 
 var (
-	storeToFile  bool
-	includeDepth int64
+	storeToFile   bool
+	includeDepth  int64
+	localeAliases []string
 )
 
 func init() {
 	funcCmd.PersistentFlags().BoolVarP(&storeToFile, "file", "f", false, "store to file")
 	funcCmd.PersistentFlags().Int64VarP(&includeDepth, "include", "i", 3, "include depth")
+	funcCmd.PersistentFlags().StringSliceVar(&localeAliases, "locale-alias", nil, "serve a locale under an alias too, e.g. en=en-GB")
 	funcCmd.AddCommand(pgFuncCmd)
 }
 
@@ -31,6 +34,7 @@ var pgFuncCmd = &cobra.Command{
 			res := &gontentful.PGSQLSchema{}
 			err = json.Unmarshal(dat, &res)
 			if err == nil {
+				addLocaleAliases(res)
 				log.Println("creating or replacing functions from cached schema...")
 				funcs := gontentful.NewPGFunctions(res)
 				err = funcs.Exec(databaseURL)
@@ -42,7 +46,7 @@ var pgFuncCmd = &cobra.Command{
 			}
 		}
 
-		client := gontentful.NewClient(&gontentful.ClientOptions{
+		client := newClient(&gontentful.ClientOptions{
 			CdnURL:        apiURL,
 			SpaceID:       spaceID,
 			EnvironmentID: environmentID,
@@ -65,6 +69,7 @@ var pgFuncCmd = &cobra.Command{
 
 		log.Println("creating postgres schema...")
 		schema := gontentful.NewPGSQLSchema(schemaName, space.Locales, "", cmaTypes.Items, includeDepth)
+		addLocaleAliases(schema)
 
 		if storeToFile {
 			s, err := json.Marshal(schema)
@@ -84,3 +89,16 @@ var pgFuncCmd = &cobra.Command{
 		log.Println("exec done")
 	},
 }
+
+func addLocaleAliases(schema *gontentful.PGSQLSchema) {
+	if schema.LocaleAliases == nil {
+		schema.LocaleAliases = make(gontentful.PGLocaleAliases)
+	}
+	for _, a := range localeAliases {
+		code, alias, ok := strings.Cut(a, "=")
+		if !ok || code == "" || alias == "" {
+			log.Fatalf("invalid locale alias %q, expected code=alias", a)
+		}
+		schema.LocaleAliases.Add(code, alias)
+	}
+}
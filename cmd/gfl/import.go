@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/moonwalker/gontentful"
+)
+
+var (
+	importFile    string
+	importReport  string
+	importOptions gontentful.ImportOptions
+)
+
+func init() {
+	importCmd.Flags().StringVarP(&importFile, "file", "f", "contentful-export.json", "export file to import")
+	importCmd.Flags().StringVarP(&importReport, "report", "r", "", "write the id mapping report to this file")
+	importCmd.Flags().BoolVar(&importOptions.SkipContentModel, "skip-content-model", false, "skip content types and editor interfaces")
+	importCmd.Flags().BoolVar(&importOptions.SkipEditorInterfaces, "skip-editor-interfaces", false, "skip editor interfaces")
+	importCmd.Flags().BoolVar(&importOptions.SkipContent, "skip-content", false, "skip entries and assets")
+	importCmd.Flags().BoolVar(&importOptions.SkipLocales, "skip-locales", false, "skip locales")
+	importCmd.Flags().BoolVar(&importOptions.SkipTags, "skip-tags", false, "skip tags")
+	importCmd.Flags().BoolVar(&importOptions.SkipWebhooks, "skip-webhooks", false, "skip webhooks")
+	importCmd.Flags().BoolVar(&importOptions.SkipContentPublishing, "skip-content-publishing", false, "leave imported entries and assets as drafts")
+	importCmd.Flags().BoolVar(&importOptions.NewIDs, "new-ids", false, "create entries and assets with new ids")
+	rootCmd.AddCommand(importCmd)
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a contentful-export json file into a space environment",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newClient(&gontentful.ClientOptions{
+			SpaceID:       spaceID,
+			EnvironmentID: environmentID,
+			CmaURL:        cmaURL,
+			CmaToken:      cmaToken,
+		})
+
+		f, err := os.Open(importFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		export, err := gontentful.ReadSpaceExport(f)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Println("import...")
+		report, err := client.Spaces.Import(export, &importOptions)
+		if report != nil && importReport != "" {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			if err := os.WriteFile(importReport, data, 0644); err != nil {
+				log.Println(err)
+			}
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, item := range report.Failed() {
+			log.Printf("%s %s failed: %s", item.Type, item.SourceID, item.Error)
+		}
+		log.Printf("imported %d items, %d failed", len(report.Items), len(report.Failed()))
+	},
+}
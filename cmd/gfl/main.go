@@ -6,6 +6,8 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
+
+	"github.com/moonwalker/gontentful"
 )
 
 var (
@@ -43,6 +45,27 @@ func init() {
 	//rootCmd.MarkFlagRequired("schema")
 }
 
+// limiters are shared by every client of the command, so commands using several clients stay within the api limits
+var (
+	cdnLimiter     = gontentful.NewRateLimiter(gontentful.CdnRateLimit, gontentful.CdnRateLimit)
+	previewLimiter = gontentful.NewRateLimiter(gontentful.PreviewRateLimit, gontentful.PreviewRateLimit)
+	cmaLimiter     = gontentful.NewRateLimiter(gontentful.CmaRateLimit, gontentful.CmaRateLimit)
+)
+
+// newClient creates a client throttled by the shared rate limiters
+func newClient(opts *gontentful.ClientOptions) *gontentful.Client {
+	if opts.CdnLimiter == nil {
+		opts.CdnLimiter = cdnLimiter
+	}
+	if opts.PreviewLimiter == nil {
+		opts.PreviewLimiter = previewLimiter
+	}
+	if opts.CmaLimiter == nil {
+		opts.CmaLimiter = cmaLimiter
+	}
+	return gontentful.NewClient(opts)
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
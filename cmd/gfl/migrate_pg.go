@@ -21,7 +21,7 @@ var pgMigrateCmd = &cobra.Command{
 			log.Println("database url must be specified")
 		}
 
-		client := gontentful.NewClient(&gontentful.ClientOptions{
+		client := newClient(&gontentful.ClientOptions{
 			CdnURL:        apiURL,
 			SpaceID:       spaceID,
 			EnvironmentID: environmentID,
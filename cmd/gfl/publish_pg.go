@@ -195,7 +195,7 @@ var pgPublishCmd = &cobra.Command{
 	Short: "Publish content",
 
 	Run: func(cmd *cobra.Command, args []string) {
-		client := gontentful.NewClient(&gontentful.ClientOptions{
+		client := newClient(&gontentful.ClientOptions{
 			CdnURL:        apiURL,
 			SpaceID:       spaceID,
 			EnvironmentID: environmentID,
@@ -18,7 +18,7 @@ var gqlSchemaCmd = &cobra.Command{
 	Short: "Creates graphql schema",
 
 	Run: func(cmd *cobra.Command, args []string) {
-		client := gontentful.NewClient(&gontentful.ClientOptions{
+		client := newClient(&gontentful.ClientOptions{
 			CdnURL:        apiURL,
 			SpaceID:       spaceID,
 			EnvironmentID: environmentID,
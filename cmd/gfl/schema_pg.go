@@ -19,7 +19,7 @@ var pgSchemaCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Println("creating postgres schema...")
 
-		client := gontentful.NewClient(&gontentful.ClientOptions{
+		client := newClient(&gontentful.ClientOptions{
 			CdnURL:        apiURL,
 			SpaceID:       spaceID,
 			EnvironmentID: environmentID,
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/moonwalker/gontentful"
+)
+
+var (
+	webhooksAddr     string
+	webhooksPath     string
+	webhooksSecret   string
+	webhooksInsecure bool
+	webhooksMatViews bool
+)
+
+func init() {
+	webhooksServeCmd.Flags().StringVarP(&webhooksAddr, "addr", "a", ":8080", "listen address")
+	webhooksServeCmd.Flags().StringVarP(&webhooksPath, "path", "p", "/webhooks", "webhook path")
+	webhooksServeCmd.Flags().StringVar(&webhooksSecret, "secret", os.Getenv("CONTENTFUL_WEBHOOK_SECRET"), "webhook signing secret (required unless --insecure)")
+	webhooksServeCmd.Flags().BoolVar(&webhooksInsecure, "insecure", false, "accept unsigned webhook requests when no secret is set")
+	webhooksServeCmd.Flags().BoolVar(&webhooksMatViews, "matviews", true, "refresh dependent materialized views")
+	webhooksServeCmd.MarkFlagRequired("schema")
+	serveCmd.AddCommand(webhooksServeCmd)
+}
+
+var webhooksServeCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Apply Contentful webhooks to the postgres schema",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if webhooksSecret == "" && !webhooksInsecure {
+			log.Fatal("no signing secret, set --secret or CONTENTFUL_WEBHOOK_SECRET (or --insecure to accept unsigned requests)")
+		}
+
+		client := newClient(&gontentful.ClientOptions{
+			CdnURL:        apiURL,
+			SpaceID:       spaceID,
+			EnvironmentID: environmentID,
+			CdnToken:      cdnToken,
+			CmaURL:        cmaURL,
+			CmaToken:      cmaToken,
+		})
+
+		log.Println("get space...")
+		space, err := client.Spaces.GetSpace()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Println("get cma types...")
+		cmaTypes, err := client.ContentTypes.GetCMATypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		mirror := gontentful.NewPGWebhooks(databaseURL, schemaName, space.Locales, cmaTypes.Items)
+		mirror.RefreshMatViews = webhooksMatViews
+
+		if webhooksSecret == "" {
+			log.Println("insecure: no signing secret, webhook requests are not verified")
+		}
+		handler := gontentful.NewWebhookHandler(webhooksSecret)
+		handler.On("*.*", func(r *http.Request, e *gontentful.WebhookEvent) error {
+			id := ""
+			if e.Sys != nil {
+				id = e.Sys.ID
+			}
+			log.Printf("%s %s...", e.Topic, id)
+			msg, err := mirror.Apply(e)
+			if err != nil {
+				log.Printf("%s %s failed: %s", e.Topic, id, err)
+				return err
+			}
+			if msg != "" {
+				log.Println(msg)
+			}
+			return nil
+		})
+
+		mux := http.NewServeMux()
+		mux.Handle(webhooksPath, handler)
+		log.Printf("listening on %s%s", webhooksAddr, webhooksPath)
+		log.Fatal(http.ListenAndServe(webhooksAddr, mux))
+	},
+}
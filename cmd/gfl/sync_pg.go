@@ -22,7 +22,7 @@ var pgSyncCmd = &cobra.Command{
 	Short: "Sync data to postgres",
 
 	Run: func(cmd *cobra.Command, args []string) {
-		client := gontentful.NewClient(&gontentful.ClientOptions{
+		client := newClient(&gontentful.ClientOptions{
 			CdnURL:        apiURL,
 			SpaceID:       spaceID,
 			EnvironmentID: environmentID,
@@ -2,6 +2,7 @@ package gontentful
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -16,12 +17,24 @@ const (
 type ContentTypesService service
 
 func (s *ContentTypesService) Get(query url.Values) ([]byte, error) {
+	return s.GetContext(context.Background(), query)
+}
+
+func (s *ContentTypesService) GetContext(ctx context.Context, query url.Values) ([]byte, error) {
 	path := fmt.Sprintf(pathContentTypes, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
-	return s.client.get(path, query)
+	return s.client.get(ctx, path, query)
 }
 
 func (s *ContentTypesService) GetTypes() (*ContentTypes, error) {
-	data, err := s.Get(nil)
+	return s.GetTypesContext(context.Background())
+}
+
+func (s *ContentTypesService) GetTypesContext(ctx context.Context) (*ContentTypes, error) {
+	return s.getTypesContext(ctx, nil)
+}
+
+func (s *ContentTypesService) getTypesContext(ctx context.Context, query url.Values) (*ContentTypes, error) {
+	data, err := s.GetContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -34,40 +47,66 @@ func (s *ContentTypesService) GetTypes() (*ContentTypes, error) {
 }
 
 func (s *ContentTypesService) GetSingle(contentTypeId string) ([]byte, error) {
+	return s.GetSingleContext(context.Background(), contentTypeId)
+}
+
+func (s *ContentTypesService) GetSingleContext(ctx context.Context, contentTypeId string) ([]byte, error) {
 	path := fmt.Sprintf(pathContentType, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentTypeId)
-	return s.client.get(path, nil)
+	return s.client.get(ctx, path, nil)
 }
 
 func (s *ContentTypesService) Update(contentType string, body []byte, version string) ([]byte, error) {
+	return s.UpdateContext(context.Background(), contentType, body, version)
+}
+
+func (s *ContentTypesService) UpdateContext(ctx context.Context, contentType string, body []byte, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathContentType, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentType)
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.put(path, bytes.NewBuffer(body))
+	return s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version))
 }
 
 func (s *ContentTypesService) Create(contentType string, body []byte) ([]byte, error) {
+	return s.CreateContext(context.Background(), contentType, body)
+}
+
+func (s *ContentTypesService) CreateContext(ctx context.Context, contentType string, body []byte) ([]byte, error) {
 	path := fmt.Sprintf(pathContentType, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentType)
-	return s.client.put(path, bytes.NewBuffer(body))
+	return s.client.put(ctx, path, bytes.NewBuffer(body))
 }
 
 func (s *ContentTypesService) Publish(contentType string, version string) ([]byte, error) {
+	return s.PublishContext(context.Background(), contentType, version)
+}
+
+func (s *ContentTypesService) PublishContext(ctx context.Context, contentType string, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathContentTypesPublish, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentType)
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.put(path, nil)
+	return s.client.put(ctx, path, nil, withVersion(version))
 }
 
 func (s *ContentTypesService) UnPublish(contentType string) ([]byte, error) {
+	return s.UnPublishContext(context.Background(), contentType)
+}
+
+func (s *ContentTypesService) UnPublishContext(ctx context.Context, contentType string) ([]byte, error) {
 	path := fmt.Sprintf(pathContentTypesPublish, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentType)
-	return s.client.delete(path)
+	return s.client.delete(ctx, path)
 }
 
 func (s *ContentTypesService) Delete(contentType string) ([]byte, error) {
+	return s.DeleteContext(context.Background(), contentType)
+}
+
+func (s *ContentTypesService) DeleteContext(ctx context.Context, contentType string) ([]byte, error) {
 	path := fmt.Sprintf(pathContentType, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentType)
-	return s.client.delete(path)
+	return s.client.delete(ctx, path)
 }
 
 func (s *ContentTypesService) GetSingleCMA(contentTypeId string) (*ContentType, error) {
+	return s.GetSingleCMAContext(context.Background(), contentTypeId)
+}
+
+func (s *ContentTypesService) GetSingleCMAContext(ctx context.Context, contentTypeId string) (*ContentType, error) {
 	path := fmt.Sprintf(pathContentType, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentTypeId)
-	data, err := s.client.getCMA(path, nil)
+	data, err := s.client.getCMA(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +119,12 @@ func (s *ContentTypesService) GetSingleCMA(contentTypeId string) (*ContentType,
 }
 
 func (s *ContentTypesService) GetCMATypes() (*ContentTypes, error) {
+	return s.GetCMATypesContext(context.Background())
+}
+
+func (s *ContentTypesService) GetCMATypesContext(ctx context.Context) (*ContentTypes, error) {
 	path := fmt.Sprintf(pathContentTypes, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
-	data, err := s.client.getCMA(path, nil)
+	data, err := s.client.getCMA(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
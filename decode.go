@@ -0,0 +1,219 @@
+package gontentful
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	decodeTagName   = "contentful"
+	decodeSysKey    = "sys"
+	defaultMaxDepth = 10
+)
+
+// dateLayouts are the formats of the Contentful Date field
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// DecodeOption configures DecodeEntry and DecodeEntries
+type DecodeOption func(d *decoder)
+
+// WithLocale picks the given locale (then the fallbacks in order) from fields
+// fetched with locale=*, without it field values are decoded as they are.
+func WithLocale(code string, fallbacks ...string) DecodeOption {
+	return func(d *decoder) {
+		d.locales = append([]string{code}, fallbacks...)
+	}
+}
+
+// WithIncludes resolves links to the included entries and assets
+func WithIncludes(includes *Include) DecodeOption {
+	return func(d *decoder) {
+		d.links.add(includes)
+	}
+}
+
+// WithMaxDepth limits how deep links are resolved, links below are left as link objects
+func WithMaxDepth(depth int) DecodeOption {
+	return func(d *decoder) {
+		d.maxDepth = depth
+	}
+}
+
+// DecodeEntry decodes the fields of an entry into T, using the `contentful`
+// struct tag for field ids. A field tagged `contentful:"sys"` receives the
+// entry Sys, linked entries and assets are decoded into struct fields when
+// they can be resolved from the includes.
+//
+//	type Game struct {
+//		Sys      *gontentful.Sys `contentful:"sys"`
+//		Slug     string          `contentful:"slug"`
+//		Provider *Provider       `contentful:"provider"`
+//	}
+func DecodeEntry[T any](e *Entry, opts ...DecodeOption) (*T, error) {
+	d := newDecoder(opts...)
+	d.links.addEntry(e)
+
+	res := new(T)
+	if err := d.decode(e, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DecodeEntries decodes every item into T, resolving links between the items and to their includes
+func DecodeEntries[T any](entries *Entries, opts ...DecodeOption) ([]T, error) {
+	d := newDecoder(opts...)
+	d.links.add(entries.Includes)
+	for _, item := range entries.Items {
+		d.links.addEntry(item)
+	}
+
+	res := make([]T, len(entries.Items))
+	for i, item := range entries.Items {
+		if err := d.decode(item, &res[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+type decoder struct {
+	locales  []string
+	maxDepth int
+	links    linkIndex
+}
+
+func newDecoder(opts ...DecodeOption) *decoder {
+	d := &decoder{
+		maxDepth: defaultMaxDepth,
+		links:    make(linkIndex),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func (d *decoder) decode(e *Entry, result interface{}) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:    decodeTagName,
+		Result:     result,
+		DecodeHook: stringToTimeHook,
+	})
+	if err != nil {
+		return err
+	}
+
+	data := d.entryMap(e, map[string]bool{}, 0)
+	if err := dec.Decode(data); err != nil {
+		id := ""
+		if e.Sys != nil {
+			id = e.Sys.ID
+		}
+		return fmt.Errorf("failed to decode entry %s: %w", id, err)
+	}
+	return nil
+}
+
+// entryMap flattens an entry into field id -> value with the links resolved,
+// path holds the entries being resolved to break cycles
+func (d *decoder) entryMap(e *Entry, path map[string]bool, depth int) map[string]interface{} {
+	m := map[string]interface{}{
+		decodeSysKey: e.Sys,
+	}
+	if e.Sys != nil {
+		path[e.Sys.ID] = true
+		defer delete(path, e.Sys.ID)
+	}
+
+	for id, v := range e.Fields {
+		value, ok := d.localize(v)
+		if !ok {
+			continue
+		}
+		m[id] = d.resolve(value, path, depth)
+	}
+	return m
+}
+
+func (d *decoder) localize(v interface{}) (interface{}, bool) {
+	if len(d.locales) == 0 {
+		return v, true
+	}
+	values, ok := v.(map[string]interface{})
+	if !ok {
+		return v, true
+	}
+	for _, code := range d.locales {
+		if lv, ok := values[code]; ok {
+			return lv, true
+		}
+	}
+	return nil, false
+}
+
+func (d *decoder) resolve(v interface{}, path map[string]bool, depth int) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if linkType, id, ok := parseLink(t); ok {
+			target := d.links.get(linkType, id)
+			if target == nil || path[id] || depth >= d.maxDepth {
+				return t
+			}
+			return d.entryMap(target, path, depth+1)
+		}
+		res := make(map[string]interface{}, len(t))
+		for k, fv := range t {
+			res[k] = d.resolve(fv, path, depth)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i, fv := range t {
+			res[i] = d.resolve(fv, path, depth)
+		}
+		return res
+	case *Entry:
+		// already resolved by ResolveLinks, turned back into a link like unresolved link objects
+		if t == nil || t.Sys == nil {
+			return nil
+		}
+		if path[t.Sys.ID] || depth >= d.maxDepth {
+			return linkMap(t.Sys)
+		}
+		return d.entryMap(t, path, depth+1)
+	}
+	return v
+}
+
+func linkMap(sys *Sys) map[string]interface{} {
+	return map[string]interface{}{
+		decodeSysKey: map[string]interface{}{
+			"type":     "Link",
+			"linkType": sys.Type,
+			"id":       sys.ID,
+		},
+	}
+}
+
+func stringToTimeHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(time.Time{}) {
+		return data, nil
+	}
+	s := data.(string)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date: %s", s)
+}
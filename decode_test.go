@@ -0,0 +1,45 @@
+package gontentful_test
+
+import (
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+type decodeNode struct {
+	Sys  *gontentful.Sys `contentful:"sys"`
+	Name string          `contentful:"name"`
+	Next *decodeNode     `contentful:"next"`
+}
+
+func TestDecodeResolvedCycleKeepsLink(t *testing.T) {
+	a := &gontentful.Entry{Sys: &gontentful.Sys{ID: "a", Type: gontentful.ENTRY}, Fields: gontentful.Fields{"name": "a"}}
+	b := &gontentful.Entry{Sys: &gontentful.Sys{ID: "b", Type: gontentful.ENTRY}, Fields: gontentful.Fields{"name": "b", "next": a}}
+	a.Fields["next"] = b
+
+	res, err := gontentful.DecodeEntry[decodeNode](a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Next == nil || res.Next.Name != "b" {
+		t.Fatalf("got next %+v, want b", res.Next)
+	}
+	back := res.Next.Next
+	if back == nil || back.Sys == nil || back.Sys.Type != "Link" || back.Sys.LinkType != gontentful.ENTRY || back.Sys.ID != "a" {
+		t.Fatalf("got %+v, want a link to a", back)
+	}
+}
+
+func TestDecodeResolvedPastMaxDepthKeepsLink(t *testing.T) {
+	c := &gontentful.Entry{Sys: &gontentful.Sys{ID: "c", Type: gontentful.ENTRY}, Fields: gontentful.Fields{"name": "c"}}
+	b := &gontentful.Entry{Sys: &gontentful.Sys{ID: "b", Type: gontentful.ENTRY}, Fields: gontentful.Fields{"name": "b", "next": c}}
+	a := &gontentful.Entry{Sys: &gontentful.Sys{ID: "a", Type: gontentful.ENTRY}, Fields: gontentful.Fields{"name": "a", "next": b}}
+
+	res, err := gontentful.DecodeEntry[decodeNode](a, gontentful.WithMaxDepth(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Next == nil || res.Next.Next == nil || res.Next.Next.Sys.ID != "c" || res.Next.Next.Name != "" {
+		t.Fatalf("got %+v, want b with an unresolved link to c", res.Next)
+	}
+}
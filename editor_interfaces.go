@@ -0,0 +1,76 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	EDITOR_INTERFACE = "EditorInterface"
+)
+
+// EditorInterface configures the web app editor of a content type, every content type has one
+type EditorInterface struct {
+	Sys      *Sys                      `json:"sys,omitempty"`
+	Controls []*EditorInterfaceControl `json:"controls,omitempty"`
+	// Sidebar, Editors, EditorLayout and GroupControls are kept as returned by the api
+	Sidebar       json.RawMessage `json:"sidebar,omitempty"`
+	Editors       json.RawMessage `json:"editors,omitempty"`
+	EditorLayout  json.RawMessage `json:"editorLayout,omitempty"`
+	GroupControls json.RawMessage `json:"groupControls,omitempty"`
+}
+
+type EditorInterfaceControl struct {
+	FieldID         string                 `json:"fieldId"`
+	WidgetID        string                 `json:"widgetId,omitempty"`
+	WidgetNamespace string                 `json:"widgetNamespace,omitempty"`
+	Settings        map[string]interface{} `json:"settings,omitempty"`
+}
+
+func (s *ContentTypesService) GetEditorInterface(contentTypeId string) (*EditorInterface, error) {
+	return s.GetEditorInterfaceContext(context.Background(), contentTypeId)
+}
+
+func (s *ContentTypesService) GetEditorInterfaceContext(ctx context.Context, contentTypeId string) (*EditorInterface, error) {
+	path := fmt.Sprintf(pathEditorInterface, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentTypeId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalEditorInterface(data)
+}
+
+// UpdateEditorInterface replaces the controls and layout of the editor of a content type
+func (s *ContentTypesService) UpdateEditorInterface(version string, contentTypeId string, ei *EditorInterface) (*EditorInterface, error) {
+	return s.UpdateEditorInterfaceContext(context.Background(), version, contentTypeId, ei)
+}
+
+func (s *ContentTypesService) UpdateEditorInterfaceContext(ctx context.Context, version string, contentTypeId string, ei *EditorInterface) (*EditorInterface, error) {
+	body, err := json.Marshal(&EditorInterface{
+		Controls:      ei.Controls,
+		Sidebar:       ei.Sidebar,
+		Editors:       ei.Editors,
+		EditorLayout:  ei.EditorLayout,
+		GroupControls: ei.GroupControls,
+	})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathEditorInterface, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentTypeId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalEditorInterface(data)
+}
+
+func unmarshalEditorInterface(data []byte) (*EditorInterface, error) {
+	res := &EditorInterface{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
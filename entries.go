@@ -2,6 +2,7 @@ package gontentful
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -15,12 +16,20 @@ const (
 type EntriesService service
 
 func (s *EntriesService) Get(query url.Values) ([]byte, error) {
+	return s.GetContext(context.Background(), query)
+}
+
+func (s *EntriesService) GetContext(ctx context.Context, query url.Values) ([]byte, error) {
 	path := fmt.Sprintf(pathEntries, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
-	return s.client.get(path, query)
+	return s.client.get(ctx, path, query)
 }
 
 func (s *EntriesService) GetEntries(query url.Values) (*Entries, error) {
-	data, err := s.Get(query)
+	return s.GetEntriesContext(context.Background(), query)
+}
+
+func (s *EntriesService) GetEntriesContext(ctx context.Context, query url.Values) (*Entries, error) {
+	data, err := s.GetContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -32,56 +41,93 @@ func (s *EntriesService) GetEntries(query url.Values) (*Entries, error) {
 	return res, nil
 }
 
+// GetCMAEntries gets the current (draft) versions of the entries from the management api
+func (s *EntriesService) GetCMAEntries(query url.Values) (*Entries, error) {
+	return s.GetCMAEntriesContext(context.Background(), query)
+}
+
+func (s *EntriesService) GetCMAEntriesContext(ctx context.Context, query url.Values) (*Entries, error) {
+	path := fmt.Sprintf(pathEntries, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	res := &Entries{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (s *EntriesService) GetSingle(entryId string) ([]byte, error) {
+	return s.GetSingleContext(context.Background(), entryId)
+}
+
+func (s *EntriesService) GetSingleContext(ctx context.Context, entryId string) ([]byte, error) {
 	path := fmt.Sprintf(pathEntry, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	return s.client.get(path, nil)
+	return s.client.get(ctx, path, nil)
 }
 
 func (s *EntriesService) Create(contentType string, body []byte) ([]byte, error) {
+	return s.CreateContext(context.Background(), contentType, body)
+}
+
+func (s *EntriesService) CreateContext(ctx context.Context, contentType string, body []byte) ([]byte, error) {
 	path := fmt.Sprintf(pathEntries, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
-	// Set header for content type
-	s.client.headers[headerContentfulContentType] = contentType
-	return s.client.post(path, bytes.NewBuffer(body))
+	return s.client.post(ctx, path, bytes.NewBuffer(body), withContentfulContentType(contentType))
 }
 
 func (s *EntriesService) Update(version string, entryId string, body []byte) ([]byte, error) {
+	return s.UpdateContext(context.Background(), version, entryId, body)
+}
+
+func (s *EntriesService) UpdateContext(ctx context.Context, version string, entryId string, body []byte) ([]byte, error) {
 	path := fmt.Sprintf(pathEntry, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	// Set header for content type
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.put(path, bytes.NewBuffer(body))
+	return s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version))
 }
 
 func (s *EntriesService) Publish(entryId string, version string) ([]byte, error) {
+	return s.PublishContext(context.Background(), entryId, version)
+}
+
+func (s *EntriesService) PublishContext(ctx context.Context, entryId string, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathEntriesPublish, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	// Set header for version
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.put(path, nil)
+	return s.client.put(ctx, path, nil, withVersion(version))
 }
 
 func (s *EntriesService) UnPublish(entryId string, version string) ([]byte, error) {
+	return s.UnPublishContext(context.Background(), entryId, version)
+}
+
+func (s *EntriesService) UnPublishContext(ctx context.Context, entryId string, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathEntriesPublish, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	// Set header for version
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.delete(path)
+	return s.client.delete(ctx, path, withVersion(version))
 }
 
 func (s *EntriesService) Delete(entryId string, version string) ([]byte, error) {
+	return s.DeleteContext(context.Background(), entryId, version)
+}
+
+func (s *EntriesService) DeleteContext(ctx context.Context, entryId string, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathEntry, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	// Set header for version
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.delete(path)
+	return s.client.delete(ctx, path, withVersion(version))
 }
 
 func (s *EntriesService) Archive(entryId string, version string) ([]byte, error) {
+	return s.ArchiveContext(context.Background(), entryId, version)
+}
+
+func (s *EntriesService) ArchiveContext(ctx context.Context, entryId string, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathEntriesArchive, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	// Set header for version
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.put(path, nil)
+	return s.client.put(ctx, path, nil, withVersion(version))
 }
 
 func (s *EntriesService) UnArchive(entryId string, version string) ([]byte, error) {
+	return s.UnArchiveContext(context.Background(), entryId, version)
+}
+
+func (s *EntriesService) UnArchiveContext(ctx context.Context, entryId string, version string) ([]byte, error) {
 	path := fmt.Sprintf(pathEntriesArchive, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
-	// Set header for version
-	s.client.headers[headerContentfulVersion] = version
-	return s.client.delete(path)
+	return s.client.delete(ctx, path, withVersion(version))
 }
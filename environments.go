@@ -0,0 +1,214 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	ENVIRONMENT       = "Environment"
+	ENVIRONMENT_ALIAS = "EnvironmentAlias"
+
+	EnvironmentStatusQueued = "queued"
+	EnvironmentStatusReady  = "ready"
+	EnvironmentStatusFailed = "failed"
+
+	defaultEnvironmentTimeout = 10 * time.Minute
+
+	headerSourceEnvironment = "X-Contentful-Source-Environment"
+)
+
+type EnvironmentSys struct {
+	Sys
+	Status             *Entry   `json:"status,omitempty"`
+	Aliases            []*Entry `json:"aliases,omitempty"`
+	AliasedEnvironment *Entry   `json:"aliasedEnvironment,omitempty"`
+}
+
+type Environment struct {
+	Sys  *EnvironmentSys `json:"sys"`
+	Name string          `json:"name"`
+}
+
+type Environments struct {
+	Sys   *Sys           `json:"sys"`
+	Total int            `json:"total"`
+	Skip  int            `json:"skip"`
+	Limit int            `json:"limit"`
+	Items []*Environment `json:"items"`
+}
+
+type EnvironmentAlias struct {
+	Sys         *Sys   `json:"sys,omitempty"`
+	Environment *Entry `json:"environment"`
+}
+
+type EnvironmentAliases struct {
+	Sys   *Sys                `json:"sys"`
+	Total int                 `json:"total"`
+	Skip  int                 `json:"skip"`
+	Limit int                 `json:"limit"`
+	Items []*EnvironmentAlias `json:"items"`
+}
+
+// Status returns queued, ready or failed
+func (e *Environment) Status() string {
+	if e.Sys == nil || e.Sys.Status == nil || e.Sys.Status.Sys == nil {
+		return ""
+	}
+	return e.Sys.Status.Sys.ID
+}
+
+// EnvironmentsService manages the environments and environment aliases of the space on the CMA
+type EnvironmentsService service
+
+func (s *EnvironmentsService) GetEnvironments() (*Environments, error) {
+	return s.GetEnvironmentsContext(context.Background())
+}
+
+func (s *EnvironmentsService) GetEnvironmentsContext(ctx context.Context) (*Environments, error) {
+	path := fmt.Sprintf(pathEnvironmentsList, s.client.Options.SpaceID)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &Environments{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *EnvironmentsService) GetSingle(environmentId string) (*Environment, error) {
+	return s.GetSingleContext(context.Background(), environmentId)
+}
+
+func (s *EnvironmentsService) GetSingleContext(ctx context.Context, environmentId string) (*Environment, error) {
+	path := fmt.Sprintf(pathEnvironment, s.client.Options.SpaceID, environmentId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalEnvironment(data)
+}
+
+// Create creates an environment as a clone of the source environment (master when empty),
+// the new environment is queued until the copy is done, see WaitReady
+func (s *EnvironmentsService) Create(environmentId string, name string, sourceEnvironmentId string) (*Environment, error) {
+	return s.CreateContext(context.Background(), environmentId, name, sourceEnvironmentId)
+}
+
+func (s *EnvironmentsService) CreateContext(ctx context.Context, environmentId string, name string, sourceEnvironmentId string) (*Environment, error) {
+	if name == "" {
+		name = environmentId
+	}
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathEnvironment, s.client.Options.SpaceID, environmentId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement), withHeader(headerSourceEnvironment, sourceEnvironmentId))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalEnvironment(data)
+}
+
+// WaitReady polls the environment until it is ready, a zero timeout waits 10 minutes
+func (s *EnvironmentsService) WaitReady(environmentId string, timeout time.Duration) (*Environment, error) {
+	return s.WaitReadyContext(context.Background(), environmentId, timeout)
+}
+
+func (s *EnvironmentsService) WaitReadyContext(ctx context.Context, environmentId string, timeout time.Duration) (*Environment, error) {
+	if timeout <= 0 {
+		timeout = defaultEnvironmentTimeout
+	}
+	var env *Environment
+	err := poll(ctx, timeout, func(ctx context.Context) (bool, error) {
+		var err error
+		env, err = s.GetSingleContext(ctx, environmentId)
+		if err != nil {
+			return false, err
+		}
+		if env.Status() == EnvironmentStatusFailed {
+			return false, fmt.Errorf("environment %s failed", environmentId)
+		}
+		return env.Status() == EnvironmentStatusReady, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("environment %s is not ready: %w", environmentId, err)
+	}
+	return env, nil
+}
+
+func (s *EnvironmentsService) Delete(environmentId string) error {
+	return s.DeleteContext(context.Background(), environmentId)
+}
+
+func (s *EnvironmentsService) DeleteContext(ctx context.Context, environmentId string) error {
+	path := fmt.Sprintf(pathEnvironment, s.client.Options.SpaceID, environmentId)
+	_, err := s.client.delete(ctx, path)
+	return err
+}
+
+func (s *EnvironmentsService) GetAliases() (*EnvironmentAliases, error) {
+	return s.GetAliasesContext(context.Background())
+}
+
+func (s *EnvironmentsService) GetAliasesContext(ctx context.Context) (*EnvironmentAliases, error) {
+	path := fmt.Sprintf(pathEnvironmentAliases, s.client.Options.SpaceID)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &EnvironmentAliases{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateAlias points the alias (e.g. master) at another environment
+func (s *EnvironmentsService) UpdateAlias(aliasId string, version string, environmentId string) (*EnvironmentAlias, error) {
+	return s.UpdateAliasContext(context.Background(), aliasId, version, environmentId)
+}
+
+func (s *EnvironmentsService) UpdateAliasContext(ctx context.Context, aliasId string, version string, environmentId string) (*EnvironmentAlias, error) {
+	body, err := json.Marshal(&EnvironmentAlias{
+		Environment: &Entry{
+			Sys: &Sys{
+				Type:     "Link",
+				LinkType: ENVIRONMENT,
+				ID:       environmentId,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathEnvironmentAlias, s.client.Options.SpaceID, aliasId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	res := &EnvironmentAlias{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func unmarshalEnvironment(data []byte) (*Environment, error) {
+	res := &Environment{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
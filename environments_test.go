@@ -0,0 +1,33 @@
+package gontentful_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestUpdateAliasBody(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"sys":{"id":"master","type":"EnvironmentAlias","version":2},"environment":{"sys":{"type":"Link","linkType":"Environment","id":"release-2"}}}`))
+	}))
+	defer srv.Close()
+
+	client := gontentful.NewClient(&gontentful.ClientOptions{SpaceID: "space", CmaURL: srv.URL, CmaToken: "token"})
+	alias, err := client.Environments.UpdateAlias("master", "1", "release-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(body, `"sys":null`) || !strings.Contains(body, `"id":"release-2"`) {
+		t.Errorf("got body %s", body)
+	}
+	if alias.Environment.Sys.ID != "release-2" {
+		t.Errorf("got alias %+v", alias)
+	}
+}
@@ -1,8 +1,20 @@
 package gontentful
 
 import (
-	"net/http"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerRequestID = "X-Contentful-Request-Id"
+
+	// maxErrorBody is the most of an error body kept on the APIError
+	maxErrorBody = 64 * 1024
 )
 
 // ErrorResponse model
@@ -31,99 +43,250 @@ type ErrorDetail struct {
 	Value   interface{} `json:"value,omitempty"`
 }
 
-// APIError model
+// PathString returns the path joined with dots, e.g. fields.title.en
+func (d *ErrorDetail) PathString() string {
+	switch p := d.Path.(type) {
+	case nil:
+		return ""
+	case string:
+		return p
+	case []interface{}:
+		parts := make([]string, 0, len(p))
+		for _, s := range p {
+			parts = append(parts, fmt.Sprint(s))
+		}
+		return strings.Join(parts, ".")
+	default:
+		return fmt.Sprint(p)
+	}
+}
+
+func (d *ErrorDetail) String() string {
+	msg := d.Details
+	if msg == "" {
+		msg = d.Name
+	}
+	if path := d.PathString(); path != "" {
+		return path + ": " + msg
+	}
+	return msg
+}
+
+// APIError is a failed api request, the typed errors below embed it and unwrap to it:
+//
+//	var apiErr *gontentful.APIError
+//	if errors.As(err, &apiErr) { ... apiErr.StatusCode, apiErr.RequestID ... }
 type APIError struct {
-	req *http.Request
-	res *http.Response
-	err *ErrorResponse
+	StatusCode int
+	// ID is the Contentful error id, e.g. NotFound, empty when the body is not a Contentful error
+	ID        string
+	Message   string
+	RequestID string
+	Details   []*ErrorDetail
+	// Body is the raw response body
+	Body     []byte
+	Request  *http.Request
+	Response *http.Response
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.ID != "" {
+		msg = e.ID + ": " + msg
+	}
+	msg = fmt.Sprintf("%d %s", e.StatusCode, msg)
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
 }
 
 // AccessTokenInvalidError for 401 errors
 type AccessTokenInvalidError struct {
-	APIError
+	*APIError
+}
+
+func (e AccessTokenInvalidError) Unwrap() error {
+	return e.APIError
 }
 
-func (e AccessTokenInvalidError) Error() string {
-	return e.APIError.err.Message
+// AccessDeniedError for 403 errors
+type AccessDeniedError struct {
+	*APIError
+}
+
+func (e AccessDeniedError) Unwrap() error {
+	return e.APIError
 }
 
 // VersionMismatchError for 409 errors
 type VersionMismatchError struct {
-	APIError
+	*APIError
 }
 
 func (e VersionMismatchError) Error() string {
-	return "Version " + e.APIError.req.Header.Get("X-Contentful-Version") + " is mismatched"
+	if e.Request != nil && e.Request.Header.Get(headerContentfulVersion) != "" {
+		return "Version " + e.Request.Header.Get(headerContentfulVersion) + " is mismatched: " + e.APIError.Error()
+	}
+	return "Version is mismatched: " + e.APIError.Error()
 }
 
-// ValidationFailedError model
+func (e VersionMismatchError) Unwrap() error {
+	return e.APIError
+}
+
+// ValidationFailedError for 422 errors, the details carry the path of every invalid field
 type ValidationFailedError struct {
-	APIError
+	*APIError
 }
 
 func (e ValidationFailedError) Error() string {
-	msg := ""
-	for _, err := range e.APIError.err.Details.Errors {
-		if err.Name == "uniqueFieldIds" || err.Name == "uniqueFieldApiNames" {
-			return msg
-			// msg += err.Value["id"].(string) + " should be unique for " + err.Value["name"].(string) + "\n"
-		}
+	msg := e.APIError.Error()
+	if len(e.Details) == 0 {
+		return msg
 	}
+	details := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		details = append(details, d.String())
+	}
+	return msg + ": " + strings.Join(details, "; ")
+}
 
-	return msg
+func (e ValidationFailedError) Unwrap() error {
+	return e.APIError
 }
 
 // NotFoundError for 404 errors
 type NotFoundError struct {
-	APIError
+	*APIError
 }
 
 func (e NotFoundError) Error() string {
-	return "the requested resource can not be found"
+	return "the requested resource can not be found: " + e.APIError.Error()
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.APIError
 }
 
-// RateLimitExceededError for rate limit errors
+// RateLimitExceededError for 429 errors
 type RateLimitExceededError struct {
-	APIError
+	*APIError
 }
 
-func (e RateLimitExceededError) Error() string {
-	return e.APIError.err.Message
+// Reset returns the wait before the next request is allowed, zero when unknown
+func (e RateLimitExceededError) Reset() time.Duration {
+	if e.Response == nil {
+		return 0
+	}
+	secs, err := strconv.Atoi(e.Response.Header.Get(headerRateLimitReset))
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
 }
 
-type BadRequestError struct{}
-type InvalidQueryError struct{}
-type AccessDeniedError struct{}
-type ServerError struct{}
+func (e RateLimitExceededError) Unwrap() error {
+	return e.APIError
+}
+
+// BadRequestError for 400 errors
+type BadRequestError struct {
+	*APIError
+}
+
+func (e BadRequestError) Unwrap() error {
+	return e.APIError
+}
+
+// InvalidQueryError for 400 errors of invalid query parameters
+type InvalidQueryError struct {
+	*APIError
+}
+
+func (e InvalidQueryError) Unwrap() error {
+	return e.APIError
+}
+
+// ServerError for 5xx errors, e.g. the html pages of gateway errors
+type ServerError struct {
+	*APIError
+}
+
+func (e ServerError) Unwrap() error {
+	return e.APIError
+}
 
 func parseError(req *http.Request, res *http.Response) error {
-	var e ErrorResponse
 	defer res.Body.Close()
-	err := json.NewDecoder(res.Body).Decode(&e)
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBody))
 	if err != nil {
 		return err
 	}
 
-	apiError := APIError{
-		req: req,
-		res: res,
-		err: &e,
+	apiError := &APIError{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get(headerRequestID),
+		Body:       body,
+		Request:    req,
+		Response:   res,
+	}
+
+	// non JSON bodies (proxies, gateway pages) only carry the status code
+	var e ErrorResponse
+	if json.Unmarshal(body, &e) == nil && e.Sys != nil {
+		apiError.ID = e.Sys.ID
+		apiError.Message = e.Message
+		if e.RequestID != "" {
+			apiError.RequestID = e.RequestID
+		}
+		if e.Details != nil {
+			apiError.Details = e.Details.Errors
+		}
 	}
 
-	switch errType := e.Sys.ID; errType {
+	switch apiError.ID {
+	case "BadRequest":
+		return BadRequestError{apiError}
+	case "InvalidQuery":
+		return InvalidQueryError{apiError}
+	case "AccessTokenInvalid":
+		return AccessTokenInvalidError{apiError}
+	case "AccessDenied":
+		return AccessDeniedError{apiError}
 	case "NotFound":
 		return NotFoundError{apiError}
+	case "VersionMismatch", "Conflict":
+		return VersionMismatchError{apiError}
+	case "ValidationFailed", "InvalidEntry", "UnresolvableLink":
+		return ValidationFailedError{apiError}
 	case "RateLimitExceeded":
 		return RateLimitExceededError{apiError}
-	case "AccessTokenInvalid":
+	case "ServerError", "BadGateway", "ServiceUnavailable":
+		return ServerError{apiError}
+	}
+
+	switch res.StatusCode {
+	case http.StatusBadRequest:
+		return BadRequestError{apiError}
+	case http.StatusUnauthorized:
 		return AccessTokenInvalidError{apiError}
-	case "ValidationFailed":
-		return ValidationFailedError{apiError}
-	case "VersionMismatch":
-		return VersionMismatchError{apiError}
-	case "Conflict":
+	case http.StatusForbidden:
+		return AccessDeniedError{apiError}
+	case http.StatusNotFound:
+		return NotFoundError{apiError}
+	case http.StatusConflict:
 		return VersionMismatchError{apiError}
-	default:
-		return e
+	case http.StatusUnprocessableEntity:
+		return ValidationFailedError{apiError}
+	case http.StatusTooManyRequests:
+		return RateLimitExceededError{apiError}
+	}
+	if res.StatusCode >= http.StatusInternalServerError {
+		return ServerError{apiError}
 	}
+	return apiError
 }
@@ -0,0 +1,69 @@
+package gontentful_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestNotFoundError(t *testing.T) {
+	_, client := newFake(t)
+
+	_, err := client.Entries.GetSingle("missing")
+	var notFound gontentful.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("got %T %v, want NotFoundError", err, err)
+	}
+	var apiErr *gontentful.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatal("NotFoundError does not unwrap to APIError")
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.ID != "NotFound" || apiErr.RequestID == "" {
+		t.Errorf("got status %d id %q request id %q", apiErr.StatusCode, apiErr.ID, apiErr.RequestID)
+	}
+}
+
+func TestValidationFailedError(t *testing.T) {
+	_, client := newFake(t)
+
+	// creating an entry needs its content type
+	_, err := client.Entries.Update("", "a", []byte(`{"fields":{}}`))
+	var invalid gontentful.ValidationFailedError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("got %T %v, want ValidationFailedError", err, err)
+	}
+	if len(invalid.Details) != 1 || !strings.Contains(err.Error(), "Missing content type") {
+		t.Errorf("got details %v, error %q", invalid.Details, err)
+	}
+}
+
+func TestVersionMismatchError(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"title": localized("t")}))
+
+	_, err := client.Entries.Update("1", "a", []byte(`{"fields":{}}`))
+	var conflict gontentful.VersionMismatchError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("got %T %v, want VersionMismatchError", err, err)
+	}
+	if conflict.StatusCode != http.StatusConflict {
+		t.Errorf("got status %d", conflict.StatusCode)
+	}
+}
+
+func TestRateLimitExceededError(t *testing.T) {
+	srv, client := newFake(t)
+	srv.InjectRateLimit(3)
+
+	_, err := client.Entries.GetEntries(nil)
+	var rateLimited gontentful.RateLimitExceededError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("got %T %v, want RateLimitExceededError", err, err)
+	}
+	if n := countRequests(srv, "/entries"); n != 3 {
+		t.Errorf("made %d requests, want 3", n)
+	}
+}
@@ -0,0 +1,174 @@
+package gontentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+const exportPageLimit = 100
+
+// SpaceExport is the layout of the json files written by contentful-export and read by contentful-import.
+// Content types are kept as returned by the management api, the ContentType model does not cover every validation.
+type SpaceExport struct {
+	ContentTypes     []json.RawMessage  `json:"contentTypes"`
+	EditorInterfaces []*EditorInterface `json:"editorInterfaces"`
+	Entries          []*Entry           `json:"entries"`
+	Assets           []*Asset           `json:"assets"`
+	Locales          []*Locale          `json:"locales"`
+	Tags             []*Tag             `json:"tags"`
+	Webhooks         []*Webhook         `json:"webhooks"`
+}
+
+// ExportOptions select the parts of the export, like the skip options of contentful-export
+type ExportOptions struct {
+	SkipContentModel     bool
+	SkipEditorInterfaces bool
+	SkipContent          bool
+	SkipTags             bool
+	SkipWebhooks         bool
+	// Query filters the exported entries only, like queryEntries of contentful-export, assets are exported unfiltered
+	Query url.Values
+}
+
+type rawContentTypes struct {
+	Total int               `json:"total"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// ReadSpaceExport reads a contentful-export json file
+func ReadSpaceExport(r io.Reader) (*SpaceExport, error) {
+	res := &SpaceExport{}
+	err := json.NewDecoder(r).Decode(res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Write writes the export as indented json
+func (e *SpaceExport) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
+
+// Export reads the content model, content, locales, tags and webhooks of the space environment
+// from the management api, entries and assets in their draft (latest) version
+func (s *SpacesService) Export(opts *ExportOptions) (*SpaceExport, error) {
+	return s.ExportContext(context.Background(), opts)
+}
+
+func (s *SpacesService) ExportContext(ctx context.Context, opts *ExportOptions) (*SpaceExport, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	res := &SpaceExport{
+		ContentTypes:     make([]json.RawMessage, 0),
+		EditorInterfaces: make([]*EditorInterface, 0),
+		Entries:          make([]*Entry, 0),
+		Assets:           make([]*Asset, 0),
+		Locales:          make([]*Locale, 0),
+		Tags:             make([]*Tag, 0),
+		Webhooks:         make([]*Webhook, 0),
+	}
+	pageOpts := &PageOptions{Limit: exportPageLimit}
+
+	locales, err := s.client.Locales.GetCMALocalesContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("export locales: %w", err)
+	}
+	res.Locales = locales.Items
+
+	if !opts.SkipContentModel {
+		path := fmt.Sprintf(pathContentTypes, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+		fetch := func(ctx context.Context, query url.Values) (*rawContentTypes, error) {
+			data, err := s.client.getCMA(ctx, path, query)
+			if err != nil {
+				return nil, err
+			}
+			page := &rawContentTypes{}
+			return page, json.Unmarshal(data, page)
+		}
+		err := paginate(ctx, url.Values{}, pageOpts, fetch, func(p *rawContentTypes) int { return p.Total }, func(p *rawContentTypes) error {
+			res.ContentTypes = append(res.ContentTypes, p.Items...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export content types: %w", err)
+		}
+
+		if !opts.SkipEditorInterfaces {
+			for _, ct := range res.ContentTypes {
+				id := rawSysID(ct)
+				ei, err := s.client.ContentTypes.GetEditorInterfaceContext(ctx, id)
+				if err != nil {
+					return nil, fmt.Errorf("export editor interface %s: %w", id, err)
+				}
+				res.EditorInterfaces = append(res.EditorInterfaces, ei)
+			}
+		}
+	}
+
+	if !opts.SkipContent {
+		query := url.Values{}
+		for k, v := range opts.Query {
+			query[k] = v
+		}
+		if query.Get("order") == "" {
+			query.Set("order", "sys.createdAt,sys.id")
+		}
+		fetch := s.client.Entries.GetCMAEntriesContext
+		err := paginate(ctx, query, pageOpts, fetch, func(p *Entries) int { return p.Total }, func(p *Entries) error {
+			res.Entries = append(res.Entries, p.Items...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export entries: %w", err)
+		}
+
+		query = url.Values{"order": []string{"sys.createdAt,sys.id"}}
+		err = paginate(ctx, query, pageOpts, s.client.Assets.GetCMAAssetsContext, func(p *Assets) int { return p.Total }, func(p *Assets) error {
+			res.Assets = append(res.Assets, p.Items...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export assets: %w", err)
+		}
+	}
+
+	if !opts.SkipTags {
+		err := paginate(ctx, url.Values{}, pageOpts, s.client.Tags.GetCMATagsContext, func(p *Tags) int { return p.Total }, func(p *Tags) error {
+			res.Tags = append(res.Tags, p.Items...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export tags: %w", err)
+		}
+	}
+
+	if !opts.SkipWebhooks {
+		err := paginate(ctx, url.Values{}, pageOpts, s.client.Webhooks.getWebhooksContext, func(p *Webhooks) int { return p.Total }, func(p *Webhooks) error {
+			res.Webhooks = append(res.Webhooks, p.Items...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export webhooks: %w", err)
+		}
+	}
+
+	return res, nil
+}
+
+// rawSysID returns the sys.id of a json object, empty when it has none
+func rawSysID(data json.RawMessage) string {
+	v := struct {
+		Sys *Sys `json:"sys"`
+	}{}
+	if json.Unmarshal(data, &v) != nil || v.Sys == nil {
+		return ""
+	}
+	return v.Sys.ID
+}
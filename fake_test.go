@@ -0,0 +1,53 @@
+package gontentful_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+	"github.com/moonwalker/gontentful/gontentfultest"
+)
+
+// newFake starts a fake space and a client retrying quickly against it
+func newFake(t *testing.T) (*gontentfultest.Server, *gontentful.Client) {
+	t.Helper()
+	srv := gontentfultest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv, fakeClient(srv)
+}
+
+func fakeClient(srv *gontentfultest.Server) *gontentful.Client {
+	opts := srv.ClientOptions()
+	opts.Retry = &gontentful.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return gontentful.NewClient(opts)
+}
+
+func link(linkType string, id string) map[string]interface{} {
+	return map[string]interface{}{"sys": map[string]interface{}{"type": "Link", "linkType": linkType, "id": id}}
+}
+
+func entry(id string, contentType string, fields gontentful.Fields) *gontentful.Entry {
+	return &gontentful.Entry{
+		Sys: &gontentful.Sys{
+			ID:          id,
+			ContentType: &gontentful.ContentType{Sys: &gontentful.Sys{ID: contentType}},
+		},
+		Fields: fields,
+	}
+}
+
+func localized(v interface{}) map[string]interface{} {
+	return map[string]interface{}{gontentful.DefaultLocale: v}
+}
+
+// countRequests counts the requests whose "METHOD path" contains s
+func countRequests(srv *gontentfultest.Server, s string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if strings.Contains(r, s) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package gontentful_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func renderFunctions(t *testing.T, schema *gontentful.PGSQLSchema) string {
+	t.Helper()
+	sql, err := gontentful.NewPGFunctions(schema).Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sql
+}
+
+func gameSchema(locales ...*gontentful.Locale) *gontentful.PGSQLSchema {
+	types := []*gontentful.ContentType{{
+		Sys:  &gontentful.Sys{ID: "game"},
+		Name: "Game",
+		Fields: []*gontentful.ContentTypeField{
+			{ID: "name", Name: "Name", Type: "Symbol", Localized: true},
+		},
+	}}
+	return gontentful.NewPGSQLSchema("content", locales, "", types, 0)
+}
+
+func TestFunctionsLocaleAliases(t *testing.T) {
+	schema := gameSchema(
+		&gontentful.Locale{Code: "en", Default: true, CFLocales: []string{"en-GB"}},
+		&gontentful.Locale{Code: "de"},
+	)
+	schema.LocaleAliases.Add("de", "de-AT", "de-CH")
+
+	sql := renderFunctions(t, schema)
+	for _, view := range []string{
+		`CREATE OR REPLACE VIEW "mv_game_en-gb" AS SELECT * FROM "mv_game_en"`,
+		`CREATE OR REPLACE VIEW "mv_game_de-at" AS SELECT * FROM "mv_game_de"`,
+		`CREATE OR REPLACE VIEW "mv_game_de-ch" AS SELECT * FROM "mv_game_de"`,
+	} {
+		if !strings.Contains(sql, view) {
+			t.Errorf("missing %s", view)
+		}
+	}
+}
+
+func TestFunctionsNoLocaleAliases(t *testing.T) {
+	sql := renderFunctions(t, gameSchema(&gontentful.Locale{Code: "en", Default: true}))
+	if strings.Contains(sql, "CREATE OR REPLACE VIEW") {
+		t.Error("alias views rendered without aliases")
+	}
+}
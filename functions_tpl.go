@@ -296,6 +296,19 @@ END $$;
 {{ template "query" . }}	
 {{-  end -}}
 --
+DO $$
+BEGIN
+	-- the result columns of a function cannot be replaced, drop it with its materialized views when they changed
+	IF EXISTS (SELECT FROM pg_proc AS pp JOIN pg_namespace AS pn ON (pp.pronamespace = pn.oid)
+		WHERE pn.nspname = current_schema() AND pp.proname = '{{ .TableName }}_view'
+		AND pp.proargnames IS DISTINCT FROM ARRAY['localearg', '_id', '_sys_id'
+		{{- range .Columns -}}
+		, '{{ if eq .ColumnName "limit" -}}_{{- end -}}{{ .ColumnName | ToLower }}'
+		{{- end -}}
+		, '_created_at', '_updated_at', '_tags']::text[]) THEN
+		DROP FUNCTION {{ .TableName }}_view(text) CASCADE;
+	END IF;
+END $$;
 CREATE OR REPLACE FUNCTION {{ .TableName }}_view(localeArg TEXT)
 RETURNS table(_id text, _sys_id text {{- range .Columns -}}
 		,
@@ -303,7 +316,8 @@ RETURNS table(_id text, _sys_id text {{- range .Columns -}}
 		{{- .ColumnName }} {{ .SqlType -}} 
 	{{- end -}}
 	, _created_at timestamp
-	, _updated_at timestamp) AS $$
+	, _updated_at timestamp
+	, _tags text[]) AS $$
 BEGIN
 	RETURN QUERY
 		SELECT
@@ -322,7 +336,8 @@ BEGIN
 			{{- end }} AS "{{ .ColumnName }}"
 		{{- end }},
 			{{ .TableName }}._created_at AS _created_at,
-			{{ .TableName }}._updated_at AS _updated_at
+			{{ .TableName }}._updated_at AS _updated_at,
+			{{ .TableName }}._tags AS _tags
 		FROM {{ .TableName }}
 		{{- range .Columns -}}
 			{{ template "join" . }}
@@ -340,7 +355,7 @@ CREATE MATERIALIZED VIEW IF NOT EXISTS "mv_{{ $t.TableName }}_{{ .Code | ToLower
 {{- end }}
 CREATE UNIQUE INDEX IF NOT EXISTS "mv_{{ $t.TableName }}_{{ .Code | ToLower }}_idx" ON "mv_{{ $t.TableName }}_{{ .Code | ToLower }}" (_id);
 --
-{{ range $cfi, $cfl := .CFLocales }}
+{{ range $cfi, $cfl := index $.LocaleAliases $l.Code }}
 CREATE OR REPLACE VIEW "mv_{{ $t.TableName }}_{{ $cfl | ToLower }}" AS SELECT * FROM "mv_{{ $t.TableName }}_{{ $l.Code | ToLower }}";
 {{- end }}
 --
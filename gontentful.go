@@ -1,54 +1,97 @@
 package gontentful
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	timeout = 30 * time.Second
 
-	pathSpaces              = "/spaces/%s"
-	pathEnvironments        = "/environments/%s"
-	pathSpacesCreate        = "/spaces"
-	pathEntries             = pathSpaces + pathEnvironments + "/entries"
-	pathEntry               = pathEntries + "/%s"
-	pathEntriesPublish      = pathEntry + "/published"
-	pathEntriesArchive      = pathEntry + "/archived"
-	pathSync                = pathSpaces + pathEnvironments + "/sync"
-	pathAssets              = pathSpaces + pathEnvironments + "/assets"
-	pathAssetsProcess       = pathAssets + "/%s/files/%s/process"
-	pathAssetsPublished     = pathAssets + "/%s/published"
-	pathUploads             = pathSpaces + "/uploads"
-	pathContentTypes        = pathSpaces + pathEnvironments + "/content_types"
-	pathContentType         = pathContentTypes + "/%s"
-	pathContentTypesPublish = pathContentType + "/published"
-	pathLocales             = pathSpaces + "/locales"
+	pollBaseDelay = 250 * time.Millisecond
+	pollMaxDelay  = 5 * time.Second
+
+	pathSpaces               = "/spaces/%s"
+	pathEnvironments         = "/environments/%s"
+	pathSpacesCreate         = "/spaces"
+	pathEnvironmentsList     = pathSpaces + "/environments"
+	pathEnvironment          = pathSpaces + pathEnvironments
+	pathEnvironmentAliases   = pathSpaces + "/environment_aliases"
+	pathEnvironmentAlias     = pathEnvironmentAliases + "/%s"
+	pathEntries              = pathSpaces + pathEnvironments + "/entries"
+	pathEntry                = pathEntries + "/%s"
+	pathEntriesPublish       = pathEntry + "/published"
+	pathEntriesArchive       = pathEntry + "/archived"
+	pathEntrySnapshots       = pathEntry + "/snapshots"
+	pathEntrySnapshot        = pathEntrySnapshots + "/%s"
+	pathSync                 = pathSpaces + pathEnvironments + "/sync"
+	pathAssets               = pathSpaces + pathEnvironments + "/assets"
+	pathAsset                = pathAssets + "/%s"
+	pathAssetsProcess        = pathAsset + "/files/%s/process"
+	pathAssetsPublished      = pathAsset + "/published"
+	pathAssetsArchived       = pathAsset + "/archived"
+	pathUploads              = pathSpaces + "/uploads"
+	pathContentTypes         = pathSpaces + pathEnvironments + "/content_types"
+	pathContentType          = pathContentTypes + "/%s"
+	pathContentTypesPublish  = pathContentType + "/published"
+	pathContentTypeSnapshots = pathContentType + "/snapshots"
+	pathEditorInterface      = pathContentType + "/editor_interface"
+	pathContentTypeSnapshot  = pathContentTypeSnapshots + "/%s"
+	pathLocales              = pathSpaces + "/locales"
+	pathEnvLocales           = pathSpaces + pathEnvironments + "/locales"
+	pathLocale               = pathEnvLocales + "/%s"
+	pathTags                 = pathSpaces + pathEnvironments + "/tags"
+	pathTag                  = pathTags + "/%s"
+	pathReleases             = pathSpaces + pathEnvironments + "/releases"
+	pathRelease              = pathReleases + "/%s"
+	pathReleasePublished     = pathRelease + "/published"
+	pathReleaseValidate      = pathRelease + "/validate"
+	pathReleaseAction        = pathRelease + "/actions/%s"
+	pathReleaseActions       = pathSpaces + pathEnvironments + "/release_actions"
+	pathScheduledActions     = pathSpaces + "/scheduled_actions"
+	pathBulkActions          = pathSpaces + pathEnvironments + "/bulk_actions/%s"
+	pathBulkAction           = pathSpaces + pathEnvironments + "/bulk_actions/actions/%s"
+	pathScheduledAction      = pathScheduledActions + "/%s"
+	pathWebhooks             = pathSpaces + "/webhook_definitions"
+	pathWebhook              = pathWebhooks + "/%s"
+	pathWebhookCalls         = pathSpaces + "/webhooks/%s/calls"
+	pathWebhookCall          = pathWebhookCalls + "/%s"
+	pathWebhookSecret        = pathSpaces + "/webhook_settings/signing_secret"
 
 	headerContentfulContentType  = "X-Contentful-Content-Type"
 	headerContentfulVersion      = "X-Contentful-Version"
 	headerContentfulOrganization = "X-Contentful-Organization"
 	headerContentType            = "Content-Type"
 	headerAuthorization          = "Authorization"
+
+	mimeDelivery    = "application/vnd.contentful.delivery.v1+json"
+	mimeManagement  = "application/vnd.contentful.management.v1+json"
+	mimeOctetStream = "application/octet-stream"
 )
 
 type Client struct {
 	client       *http.Client
-	headers      map[string]string
 	Options      *ClientOptions
 	AfterRequest func(c *Client, req *http.Request, res *http.Response, elapsed time.Duration)
 
-	common       service
-	Entries      *EntriesService
-	Spaces       *SpacesService
-	Locales      *LocalesService
-	Assets       *AssetsService
-	Uploads      *UploadsService
-	ContentTypes *ContentTypesService
+	common           service
+	Entries          *EntriesService
+	Spaces           *SpacesService
+	Locales          *LocalesService
+	Assets           *AssetsService
+	Uploads          *UploadsService
+	ContentTypes     *ContentTypesService
+	Environments     *EnvironmentsService
+	Webhooks         *WebhooksService
+	Tags             *TagsService
+	Releases         *ReleasesService
+	ScheduledActions *ScheduledActionsService
+	BulkActions      *BulkActionsService
 }
 
 type service struct {
@@ -62,21 +105,42 @@ type ClientOptions struct {
 	CdnToken      string
 	PreviewToken  string
 	CmaToken      string
-	CdnURL        string
-	PreviewURL    string
-	CmaURL        string
-	UsePreview    bool
+	// CdnURL, PreviewURL and CmaURL are either a host (https is assumed)
+	// or a full base url like http://localhost:8080/prefix
+	CdnURL     string
+	PreviewURL string
+	CmaURL     string
+	UsePreview bool
+
+	// HTTPClient replaces the default client (30s timeout), Transport replaces
+	// the RoundTripper of the default client or of a copy of HTTPClient
+	HTTPClient *http.Client
+	Transport  http.RoundTripper
+
+	Retry *RetryPolicy
+
+	// optional client side rate limiters per API, nil means unlimited
+	CdnLimiter     *RateLimiter
+	PreviewLimiter *RateLimiter
+	CmaLimiter     *RateLimiter
 }
 
 func NewClient(options *ClientOptions) *Client {
-	httpClient := &http.Client{
-		Timeout: timeout,
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout:   timeout,
+			Transport: options.Transport,
+		}
+	} else if options.Transport != nil {
+		cp := *httpClient
+		cp.Transport = options.Transport
+		httpClient = &cp
 	}
 
 	client := &Client{
 		Options: options,
 		client:  httpClient,
-		headers: getHeadersMap(options.OrgID),
 	}
 
 	client.common.client = client
@@ -86,18 +150,50 @@ func NewClient(options *ClientOptions) *Client {
 	client.Assets = (*AssetsService)(&client.common)
 	client.Uploads = (*UploadsService)(&client.common)
 	client.ContentTypes = (*ContentTypesService)(&client.common)
+	client.Environments = (*EnvironmentsService)(&client.common)
+	client.Webhooks = (*WebhooksService)(&client.common)
+	client.Tags = (*TagsService)(&client.common)
+	client.Releases = (*ReleasesService)(&client.common)
+	client.ScheduledActions = (*ScheduledActionsService)(&client.common)
+	client.BulkActions = (*BulkActionsService)(&client.common)
 
 	return client
 }
 
-func getHeadersMap(orgID string) map[string]string {
-	return map[string]string{
-		headerContentfulOrganization: orgID,
-		headerContentType:            "application/vnd.contentful.delivery.v1+json",
+// reqOption sets per-call request headers, so concurrent calls on a shared
+// Client never see each other's version or content type.
+type reqOption func(h http.Header)
+
+func withHeader(key string, value string) reqOption {
+	return func(h http.Header) {
+		if value != "" {
+			h.Set(key, value)
+		}
+	}
+}
+
+func withVersion(version string) reqOption {
+	return withHeader(headerContentfulVersion, version)
+}
+
+func withContentType(contentType string) reqOption {
+	return withHeader(headerContentType, contentType)
+}
+
+func withContentfulContentType(contentType string) reqOption {
+	return withHeader(headerContentfulContentType, contentType)
+}
+
+func (c *Client) defaultHeaders() http.Header {
+	h := http.Header{}
+	if c.Options.OrgID != "" {
+		h.Set(headerContentfulOrganization, c.Options.OrgID)
 	}
+	h.Set(headerContentType, mimeDelivery)
+	return h
 }
 
-func (c *Client) get(path string, query url.Values) ([]byte, error) {
+func (c *Client) get(ctx context.Context, path string, query url.Values, opts ...reqOption) ([]byte, error) {
 	host := ""
 	authToken := ""
 	if c.Options.UsePreview {
@@ -107,57 +203,103 @@ func (c *Client) get(path string, query url.Values) ([]byte, error) {
 		host = c.Options.CdnURL
 		authToken = c.Options.CdnToken
 	}
-	return c.req(http.MethodGet, path, query, nil, host, authToken)
+	return c.req(ctx, http.MethodGet, path, query, nil, host, authToken, c.cdaLimiter(), opts...)
+}
+
+func (c *Client) getCMA(ctx context.Context, path string, query url.Values, opts ...reqOption) ([]byte, error) {
+	return c.req(ctx, http.MethodGet, path, query, nil, c.Options.CmaURL, c.Options.CmaToken, c.Options.CmaLimiter, opts...)
 }
 
-func (c *Client) getCMA(path string, query url.Values) ([]byte, error) {
-	return c.req(http.MethodGet, path, query, nil, c.Options.CmaURL, c.Options.CmaToken)
+func (c *Client) post(ctx context.Context, path string, body io.Reader, opts ...reqOption) ([]byte, error) {
+	return c.req(ctx, http.MethodPost, path, nil, body, c.Options.CmaURL, c.Options.CmaToken, c.Options.CmaLimiter, opts...)
 }
 
-func (c *Client) post(path string, body io.Reader) ([]byte, error) {
-	return c.req(http.MethodPost, path, nil, body, c.Options.CmaURL, c.Options.CmaToken)
+func (c *Client) put(ctx context.Context, path string, body io.Reader, opts ...reqOption) ([]byte, error) {
+	return c.req(ctx, http.MethodPut, path, nil, body, c.Options.CmaURL, c.Options.CmaToken, c.Options.CmaLimiter, opts...)
 }
 
-func (c *Client) put(path string, body io.Reader) ([]byte, error) {
-	return c.req(http.MethodPut, path, nil, body, c.Options.CmaURL, c.Options.CmaToken)
+func (c *Client) delete(ctx context.Context, path string, opts ...reqOption) ([]byte, error) {
+	return c.deleteQuery(ctx, path, nil, opts...)
 }
 
-func (c *Client) delete(path string) ([]byte, error) {
-	return c.req(http.MethodDelete, path, nil, nil, c.Options.CmaURL, c.Options.CmaToken)
+func (c *Client) deleteQuery(ctx context.Context, path string, query url.Values, opts ...reqOption) ([]byte, error) {
+	return c.req(ctx, http.MethodDelete, path, query, nil, c.Options.CmaURL, c.Options.CmaToken, c.Options.CmaLimiter, opts...)
 }
 
-func (c *Client) req(method string, path string, query url.Values, body io.Reader, host string, authToken string) ([]byte, error) {
-	u := &url.URL{
-		Scheme: "https",
-		Host:   host,
-		Path:   path,
+func (c *Client) req(ctx context.Context, method string, path string, query url.Values, body io.Reader, host string, authToken string, limiter *RateLimiter, opts ...reqOption) ([]byte, error) {
+	u, err := baseURL(host)
+	if err != nil {
+		return nil, err
 	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
 	u.RawQuery = query.Encode()
 
 	// fmt.Println(fmt.Sprintf("%s%s?%s", host, path, u.RawQuery))
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
+	setGetBody(req, body)
 
 	// set headers
-	for key, value := range c.headers {
-		if value != "" {
-			req.Header.Set(key, value)
-		}
+	req.Header = c.defaultHeaders()
+	for _, opt := range opts {
+		opt(req.Header)
 	}
 	// fmt.Println(fmt.Sprintf("%s: Bearer %s", headerAuthorization, authToken))
 	// add auth header
 	req.Header.Set(headerAuthorization, fmt.Sprintf("Bearer %s", authToken))
-	return c.do(req)
+	return c.do(req, limiter)
 }
 
-func (c *Client) do(req *http.Request) ([]byte, error) {
+// baseURL accepts a bare host (defaulting to https) as well as a full base url
+// with scheme and path prefix, e.g. http://127.0.0.1:8080/contentful
+func baseURL(base string) (*url.URL, error) {
+	if !strings.Contains(base, "://") {
+		return &url.URL{
+			Scheme: "https",
+			Host:   base,
+		}, nil
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url %s: %w", base, err)
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u, nil
+}
+
+func (c *Client) do(req *http.Request, limiter *RateLimiter) ([]byte, error) {
+	policy := c.retryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		data, res, err := c.send(req)
+		if err == nil {
+			return data, nil
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(req, res, err) || !rewind(req) {
+			return nil, err
+		}
+
+		// wait before retrying, unless the request context is done first
+		if err := sleep(req.Context(), policy.delay(attempt, res)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(req *http.Request) ([]byte, *http.Response, error) {
 	start := time.Now()
 	res, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
@@ -166,33 +308,42 @@ func (c *Client) do(req *http.Request) ([]byte, error) {
 	}
 
 	if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusBadRequest {
-		// clear headers so that they will not infect the next request.
-		c.headers = getHeadersMap(c.Options.OrgID)
-		// return the response
-		return io.ReadAll(res.Body)
+		data, err := io.ReadAll(res.Body)
+		return data, res, err
 	}
 
-	apiError := parseError(req, res)
-
-	// return apiError if it is not rate limit error
-	if _, ok := apiError.(RateLimitExceededError); !ok {
-		return nil, apiError
-	}
+	return nil, res, parseError(req, res)
+}
 
-	resetHeader := res.Header.Get("x-contentful-ratelimit-reset")
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
 
-	// return apiError if Ratelimit-Reset header is not presented
-	if resetHeader == "" {
-		return nil, apiError
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
+}
 
-	// wait X-Contentful-Ratelimit-Reset amount of seconds
-	waitSeconds, err := strconv.Atoi(resetHeader)
-	if err != nil {
-		return nil, apiError
-	}
+// poll calls done with exponential backoff until it reports true, fails or the timeout passes
+func poll(ctx context.Context, timeout time.Duration, done func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// retry on rate limit
-	time.Sleep(time.Second * time.Duration(waitSeconds))
-	return c.do(req)
+	delay := pollBaseDelay
+	for {
+		ok, err := done(ctx)
+		if err != nil || ok {
+			return err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return fmt.Errorf("gave up after %s: %w", timeout, err)
+		}
+		delay *= 2
+		if delay > pollMaxDelay {
+			delay = pollMaxDelay
+		}
+	}
 }
@@ -0,0 +1,65 @@
+package gontentfultest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+)
+
+// process starts processing the uploaded file of an asset locale,
+// the url appears once ProcessingDelay has passed
+func (s *Server) process(w http.ResponseWriter, e *entity, locale string) {
+	file := assetFile(e, locale)
+	if file == nil || uploadID(file) == "" {
+		validationFailed(w, fmt.Sprintf("No upload to process for locale %s", locale))
+		return
+	}
+	e.processing[locale] = time.Now().Add(s.ProcessingDelay)
+	s.settle(e)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// settle finishes the processing of asset files that are due
+func (s *Server) settle(e *entity) {
+	for locale, ready := range e.processing {
+		if time.Now().Before(ready) {
+			continue
+		}
+		delete(e.processing, locale)
+
+		file := assetFile(e, locale)
+		if file == nil {
+			continue
+		}
+		id := uploadID(file)
+		fileName, _ := file["fileName"].(string)
+		file["url"] = fmt.Sprintf("//assets.ctfassets.test/%s/%s/%s/%s", s.SpaceID, e.sys.ID, id, fileName)
+		file["details"] = map[string]interface{}{
+			"size": len(s.uploads[id]),
+		}
+		delete(file, "upload")
+		delete(file, "uploadFrom")
+		e.sys.Version++
+	}
+}
+
+func assetFile(e *entity, locale string) map[string]interface{} {
+	files, _ := e.fields["file"].(map[string]interface{})
+	file, _ := files[locale].(map[string]interface{})
+	return file
+}
+
+// uploadID returns the upload referenced by either uploadFrom or an upload url
+func uploadID(file map[string]interface{}) string {
+	if from, ok := file["uploadFrom"].(map[string]interface{}); ok {
+		if sys, ok := from["sys"].(map[string]interface{}); ok {
+			id, _ := sys["id"].(string)
+			return id
+		}
+	}
+	if u, ok := file["upload"].(string); ok && u != "" {
+		return path.Base(u)
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/moonwalker/gontentful"
+)
+
+// bulkAction is applied when created, the first read still reports it in progress
+type bulkAction struct {
+	action *gontentful.BulkAction
+	read   bool
+}
+
+func (s *Server) serveBulkActions(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement || len(rest) == 0 {
+		notFound(w)
+		return
+	}
+
+	if rest[0] == "actions" {
+		if len(rest) != 2 {
+			notFound(w)
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		a := s.bulkActions[rest[1]]
+		if a == nil {
+			notFound(w)
+			return
+		}
+		if !a.read {
+			a.read = true
+			writeJSON(w, http.StatusOK, inProgress(a.action))
+			return
+		}
+		writeJSON(w, http.StatusOK, a.action)
+		return
+	}
+
+	if len(rest) != 1 {
+		notFound(w)
+		return
+	}
+	action := rest[0]
+	if action != gontentful.BulkActionPublish && action != gontentful.BulkActionUnpublish && action != gontentful.BulkActionValidate {
+		notFound(w)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+	payload := &gontentful.BulkActionPayload{}
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil || payload.Entities == nil {
+		validationFailed(w, "entities are required")
+		return
+	}
+	if len(payload.Entities.Items) > gontentful.MaxBulkItems {
+		validationFailed(w, "too many entities")
+		return
+	}
+
+	at := now()
+	a := &gontentful.BulkAction{
+		Sys: &gontentful.BulkActionSys{
+			Sys: gontentful.Sys{
+				ID:        s.nextID("bulk"),
+				Type:      gontentful.BULK_ACTION,
+				CreatedAt: at,
+				UpdatedAt: at,
+			},
+			Status: gontentful.BulkActionStatusSucceeded,
+		},
+		Action:  action,
+		Payload: payload,
+	}
+	s.runBulkAction(a)
+	s.bulkActions[a.Sys.ID] = &bulkAction{action: a}
+	writeJSON(w, http.StatusCreated, inProgress(a))
+}
+
+// runBulkAction checks every entity first and applies none of them if any fails, like Contentful
+func (s *Server) runBulkAction(a *gontentful.BulkAction) {
+	type target struct {
+		e    *entity
+		link *gontentful.Entry
+	}
+	targets := make([]target, 0, len(a.Payload.Entities.Items))
+	errs := make([]*gontentful.BulkEntityError, 0)
+	for _, link := range a.Payload.Entities.Items {
+		if link == nil || link.Sys == nil {
+			continue
+		}
+		e, msg := s.bulkTarget(a.Action, link.Sys)
+		if msg != "" {
+			errs = append(errs, &gontentful.BulkEntityError{
+				Error:  &gontentful.ActionError{Sys: &gontentful.Sys{Type: "Error", ID: msg}},
+				Entity: link,
+			})
+			continue
+		}
+		targets = append(targets, target{e: e, link: link})
+	}
+
+	if len(errs) > 0 {
+		details, _ := json.Marshal(map[string]interface{}{"errors": errs})
+		a.Sys.Status = gontentful.BulkActionStatusFailed
+		a.Error = &gontentful.ActionError{
+			Sys:     &gontentful.Sys{Type: "Error", ID: "BulkActionFailed"},
+			Message: strconv.Itoa(len(errs)) + " of the entities failed",
+			Details: details,
+		}
+		return
+	}
+	for _, t := range targets {
+		switch a.Action {
+		case gontentful.BulkActionPublish:
+			s.publish(t.e)
+		case gontentful.BulkActionUnpublish:
+			s.unpublish(t.e)
+		}
+	}
+}
+
+// bulkTarget returns the entity of the link or the error id it fails with
+func (s *Server) bulkTarget(action string, link *gontentful.Sys) (*entity, string) {
+	e := s.linked(link.LinkType, link.ID)
+	if e == nil {
+		return nil, "NotFound"
+	}
+	switch action {
+	case gontentful.BulkActionPublish:
+		if link.Version != e.sys.Version {
+			return nil, "VersionMismatch"
+		}
+		if e.archived {
+			return nil, "BadRequest"
+		}
+	case gontentful.BulkActionUnpublish:
+		if e.published == nil {
+			return nil, "BadRequest"
+		}
+	case gontentful.BulkActionValidate:
+		if e.archived {
+			return nil, "BadRequest"
+		}
+	}
+	return e, ""
+}
+
+// linked returns the entry or asset a link points to or nil
+func (s *Server) linked(linkType string, id string) *entity {
+	switch linkType {
+	case gontentful.ENTRY:
+		return s.entries[id]
+	case gontentful.ASSET:
+		return s.assets[id]
+	}
+	return nil
+}
+
+func inProgress(a *gontentful.BulkAction) *gontentful.BulkAction {
+	res := *a
+	sys := *a.Sys
+	sys.Status = gontentful.BulkActionStatusInProgress
+	res.Sys = &sys
+	res.Error = nil
+	return &res
+}
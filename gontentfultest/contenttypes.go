@@ -0,0 +1,219 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/moonwalker/gontentful"
+)
+
+type contentType struct {
+	order     int
+	ct        *gontentful.ContentType
+	published *gontentful.ContentType
+	snapshots []*gontentful.ContentTypeSnapshot
+}
+
+func (s *Server) putContentType(id string, body *gontentful.ContentType) *contentType {
+	c := s.contentTypes[id]
+	if c == nil {
+		s.seq++
+		at := now()
+		c = &contentType{
+			order: s.seq,
+			ct: &gontentful.ContentType{
+				Sys: &gontentful.Sys{ID: id, Type: gontentful.CONTENT_TYPE, CreatedAt: at},
+			},
+		}
+		s.contentTypes[id] = c
+	}
+	c.ct.Name = body.Name
+	c.ct.Description = body.Description
+	c.ct.DisplayField = body.DisplayField
+	c.ct.Fields = body.Fields
+	c.ct.Sys.Version++
+	c.ct.Sys.UpdatedAt = now()
+	return c
+}
+
+func (c *contentType) publish(at string) {
+	c.ct.Sys.PublishedCounter++
+	c.ct.Sys.PublishedVersion = c.ct.Sys.Version
+	c.ct.Sys.PublishedAt = at
+	if c.ct.Sys.FirstPublishedAt == "" {
+		c.ct.Sys.FirstPublishedAt = at
+	}
+	c.ct.Sys.Version++
+	c.published = c.delivery()
+	c.snapshots = append(c.snapshots, &gontentful.ContentTypeSnapshot{
+		Sys: &gontentful.SnapshotSys{
+			Sys: gontentful.Sys{
+				ID:        fmt.Sprintf("%s-%d", c.ct.Sys.ID, c.ct.Sys.PublishedCounter),
+				Type:      gontentful.SNAPSHOT,
+				CreatedAt: at,
+				UpdatedAt: at,
+			},
+			SnapshotType:       gontentful.SnapshotTypePublish,
+			SnapshotEntityType: gontentful.CONTENT_TYPE,
+		},
+		Snapshot: c.management(),
+	})
+}
+
+func (c *contentType) management() *gontentful.ContentType {
+	res := *c.ct
+	sys := *c.ct.Sys
+	res.Sys = &sys
+	return &res
+}
+
+func (c *contentType) delivery() *gontentful.ContentType {
+	res := *c.ct
+	res.Sys = &gontentful.Sys{
+		ID:        c.ct.Sys.ID,
+		Type:      gontentful.CONTENT_TYPE,
+		CreatedAt: c.ct.Sys.CreatedAt,
+		UpdatedAt: c.ct.Sys.UpdatedAt,
+		Revision:  c.ct.Sys.PublishedCounter,
+	}
+	return &res
+}
+
+// view returns the content type as seen on the api or nil
+func (c *contentType) view(api string) *gontentful.ContentType {
+	switch api {
+	case apiManagement:
+		return c.management()
+	case apiPreview:
+		return c.delivery()
+	}
+	return c.published
+}
+
+func (s *Server) serveContentTypes(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if len(rest) == 0 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		list := make([]*contentType, 0)
+		for _, c := range s.contentTypes {
+			list = append(list, c)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].order < list[j].order
+		})
+		res := &gontentful.ContentTypes{Items: make([]*gontentful.ContentType, 0)}
+		for _, c := range list {
+			if ct := c.view(api); ct != nil {
+				res.Items = append(res.Items, ct)
+			}
+		}
+		res.Total = len(res.Items)
+		res.Limit = maxLimit
+		writeJSON(w, http.StatusOK, res)
+		return
+	}
+
+	id := rest[0]
+	c := s.contentTypes[id]
+
+	if len(rest) == 1 {
+		switch {
+		case r.Method == http.MethodGet:
+			if c == nil || c.view(api) == nil {
+				notFound(w)
+				return
+			}
+			writeJSON(w, http.StatusOK, c.view(api))
+		case r.Method == http.MethodPut && api == apiManagement:
+			body := &gontentful.ContentType{}
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+				return
+			}
+			status := http.StatusCreated
+			if c != nil {
+				if !checkVersion(w, r, c.ct.Sys, true) {
+					return
+				}
+				status = http.StatusOK
+			}
+			c = s.putContentType(id, body)
+			writeJSON(w, status, c.management())
+		case r.Method == http.MethodDelete && api == apiManagement:
+			if c == nil {
+				notFound(w)
+				return
+			}
+			if c.published != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Cannot delete published content type")
+				return
+			}
+			delete(s.contentTypes, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	if c != nil && api == apiManagement && rest[1] == "snapshots" && r.Method == http.MethodGet {
+		c.serveSnapshots(w, rest[2:])
+		return
+	}
+
+	if c == nil || api != apiManagement || rest[1] != "published" {
+		notFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if !checkVersion(w, r, c.ct.Sys, true) {
+			return
+		}
+		c.publish(now())
+	case http.MethodDelete:
+		if c.published == nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Not published")
+			return
+		}
+		c.published = nil
+		c.ct.Sys.PublishedVersion = 0
+		c.ct.Sys.Version++
+	default:
+		methodNotAllowed(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, c.management())
+}
+
+func (c *contentType) serveSnapshots(w http.ResponseWriter, rest []string) {
+	if len(rest) > 1 {
+		notFound(w)
+		return
+	}
+	if len(rest) == 1 {
+		for _, sn := range c.snapshots {
+			if sn.Sys.ID == rest[0] {
+				writeJSON(w, http.StatusOK, sn)
+				return
+			}
+		}
+		notFound(w)
+		return
+	}
+	items := make([]*gontentful.ContentTypeSnapshot, 0, len(c.snapshots))
+	for i := len(c.snapshots) - 1; i >= 0; i-- {
+		items = append(items, c.snapshots[i])
+	}
+	writeJSON(w, http.StatusOK, &gontentful.ContentTypeSnapshots{
+		Sys:   &gontentful.Sys{Type: "Array"},
+		Total: len(items),
+		Limit: maxLimit,
+		Items: items,
+	})
+}
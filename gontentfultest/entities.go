@@ -0,0 +1,602 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// entity is an entry or asset with its management state and published snapshot
+type entity struct {
+	order      int
+	sys        *gontentful.Sys
+	fields     gontentful.Fields
+	published  *gontentful.Entry
+	snapshots  []*gontentful.Snapshot
+	archived   bool
+	processing map[string]time.Time
+}
+
+func (s *Server) create(store map[string]*entity, kind string, id string, contentTypeID string, fields gontentful.Fields) *entity {
+	s.seq++
+	if id == "" {
+		id = fmt.Sprintf("%s%d", strings.ToLower(kind), s.seq)
+	}
+	at := now()
+	sys := &gontentful.Sys{
+		ID:        id,
+		Type:      kind,
+		Version:   1,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+	if contentTypeID != "" {
+		sys.ContentType = contentTypeLink(contentTypeID)
+	}
+	e := &entity{
+		order:      s.seq,
+		sys:        sys,
+		fields:     copyFields(fields),
+		processing: make(map[string]time.Time),
+	}
+	store[id] = e
+	return e
+}
+
+func (s *Server) publish(e *entity) {
+	at := now()
+	e.sys.PublishedCounter++
+	e.sys.PublishedVersion = e.sys.Version
+	e.sys.PublishedAt = at
+	if e.sys.FirstPublishedAt == "" {
+		e.sys.FirstPublishedAt = at
+	}
+	e.sys.Version++
+	e.published = e.delivery(e.sys.PublishedCounter)
+	if e.sys.Type == gontentful.ENTRY {
+		e.snapshots = append(e.snapshots, e.snapshot(at))
+	}
+	s.events = append(s.events, e.published)
+}
+
+// snapshot records the published state, entries are the only entities with snapshots
+func (e *entity) snapshot(at string) *gontentful.Snapshot {
+	m := e.management()
+	data, _ := json.Marshal(m)
+	entry := &gontentful.PublishedEntry{}
+	json.Unmarshal(data, entry)
+	return &gontentful.Snapshot{
+		Sys: &gontentful.SnapshotSys{
+			Sys: gontentful.Sys{
+				ID:        fmt.Sprintf("%s-%d", e.sys.ID, e.sys.PublishedCounter),
+				Type:      gontentful.SNAPSHOT,
+				CreatedAt: at,
+				UpdatedAt: at,
+			},
+			SnapshotType:       gontentful.SnapshotTypePublish,
+			SnapshotEntityType: e.sys.Type,
+		},
+		Snapshot: entry,
+	}
+}
+
+func (s *Server) unpublish(e *entity) {
+	e.published = nil
+	e.sys.PublishedVersion = 0
+	e.sys.PublishedAt = ""
+	e.sys.Version++
+	s.events = append(s.events, &gontentful.Entry{
+		Sys: &gontentful.Sys{
+			ID:        e.sys.ID,
+			Type:      "Deleted" + e.sys.Type,
+			CreatedAt: e.sys.CreatedAt,
+			DeletedAt: now(),
+		},
+	})
+}
+
+// management returns the entity in the management api format
+func (e *entity) management() *gontentful.Entry {
+	sys := *e.sys
+	return &gontentful.Entry{
+		Sys:    &sys,
+		Fields: copyFields(e.fields),
+	}
+}
+
+// delivery returns the current fields in the delivery api format
+func (e *entity) delivery(revision int) *gontentful.Entry {
+	return &gontentful.Entry{
+		Sys: &gontentful.Sys{
+			ID:          e.sys.ID,
+			Type:        e.sys.Type,
+			CreatedAt:   e.sys.CreatedAt,
+			UpdatedAt:   now(),
+			Revision:    revision,
+			ContentType: e.sys.ContentType,
+		},
+		Fields: copyFields(e.fields),
+	}
+}
+
+func (s *Server) serveEntities(w http.ResponseWriter, r *http.Request, api string, store map[string]*entity, kind string, rest []string) {
+	if kind == gontentful.ASSET {
+		for _, a := range store {
+			s.settle(a)
+		}
+	}
+
+	if len(rest) == 0 {
+		switch {
+		case r.Method == http.MethodGet:
+			s.listEntities(w, r, api, store)
+		case r.Method == http.MethodPost && api == apiManagement:
+			s.putEntity(w, r, store, kind, "")
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	id := rest[0]
+	if len(rest) == 1 {
+		switch {
+		case r.Method == http.MethodGet:
+			e := s.visible(store, api, id)
+			if e == nil {
+				notFound(w)
+				return
+			}
+			writeJSON(w, http.StatusOK, s.localized(e, api, r.URL.Query().Get("locale")))
+		case r.Method == http.MethodPut && api == apiManagement:
+			s.putEntity(w, r, store, kind, id)
+		case r.Method == http.MethodDelete && api == apiManagement:
+			e := store[id]
+			if e == nil {
+				notFound(w)
+				return
+			}
+			if !checkVersion(w, r, e.sys, false) {
+				return
+			}
+			if e.published != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Cannot delete published")
+				return
+			}
+			delete(store, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	e := store[id]
+	if api != apiManagement || e == nil {
+		notFound(w)
+		return
+	}
+
+	switch rest[1] {
+	case "published":
+		switch r.Method {
+		case http.MethodPut:
+			if !checkVersion(w, r, e.sys, true) {
+				return
+			}
+			if e.archived {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Cannot publish archived")
+				return
+			}
+			s.publish(e)
+		case http.MethodDelete:
+			if !checkVersion(w, r, e.sys, false) {
+				return
+			}
+			if e.published == nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Not published")
+				return
+			}
+			s.unpublish(e)
+		default:
+			methodNotAllowed(w)
+			return
+		}
+	case "archived":
+		if !checkVersion(w, r, e.sys, false) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			if e.published != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Cannot archive published")
+				return
+			}
+			e.archived = true
+		case http.MethodDelete:
+			e.archived = false
+		default:
+			methodNotAllowed(w)
+			return
+		}
+		e.sys.Version++
+	case "snapshots":
+		if kind != gontentful.ENTRY || r.Method != http.MethodGet || len(rest) > 3 {
+			notFound(w)
+			return
+		}
+		if len(rest) == 3 {
+			for _, sn := range e.snapshots {
+				if sn.Sys.ID == rest[2] {
+					writeJSON(w, http.StatusOK, sn)
+					return
+				}
+			}
+			notFound(w)
+			return
+		}
+		items := make([]*gontentful.Snapshot, 0, len(e.snapshots))
+		for i := len(e.snapshots) - 1; i >= 0; i-- {
+			items = append(items, e.snapshots[i])
+		}
+		writeJSON(w, http.StatusOK, &gontentful.Snapshots{
+			Sys:   &gontentful.Sys{Type: "Array"},
+			Total: len(items),
+			Limit: maxLimit,
+			Items: items,
+		})
+		return
+	case "files":
+		if kind != gontentful.ASSET || len(rest) != 4 || rest[3] != "process" || r.Method != http.MethodPut {
+			notFound(w)
+			return
+		}
+		if !checkVersion(w, r, e.sys, true) {
+			return
+		}
+		s.process(w, e, rest[2])
+		return
+	default:
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, e.management())
+}
+
+func (s *Server) putEntity(w http.ResponseWriter, r *http.Request, store map[string]*entity, kind string, id string) {
+	body := &gontentful.Entry{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	e := store[id]
+	if e == nil {
+		ct := r.Header.Get(headerContentType)
+		if kind == gontentful.ENTRY && ct == "" {
+			validationFailed(w, "Missing content type")
+			return
+		}
+		e = s.create(store, kind, id, ct, body.Fields)
+		writeJSON(w, http.StatusCreated, e.management())
+		return
+	}
+
+	if !checkVersion(w, r, e.sys, true) {
+		return
+	}
+	e.fields = copyFields(body.Fields)
+	e.sys.Version++
+	e.sys.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, e.management())
+}
+
+// visible returns the entity if it can be seen on the given api
+func (s *Server) visible(store map[string]*entity, api string, id string) *entity {
+	e := store[id]
+	if e == nil {
+		return nil
+	}
+	switch api {
+	case apiDelivery:
+		if e.published == nil {
+			return nil
+		}
+	case apiPreview:
+		if e.archived {
+			return nil
+		}
+	}
+	return e
+}
+
+// localized returns the entity as seen on the api, in a single locale unless locale is empty or *
+func (s *Server) localized(e *entity, api string, locale string) *gontentful.Entry {
+	var res *gontentful.Entry
+	switch api {
+	case apiManagement:
+		return e.management()
+	case apiDelivery:
+		res = &gontentful.Entry{Sys: e.published.Sys, Fields: copyFields(e.published.Fields)}
+	default:
+		res = e.delivery(e.sys.PublishedCounter)
+	}
+	if locale == "*" {
+		return res
+	}
+	if locale == "" {
+		locale = s.defaultLocale()
+	}
+
+	fields := make(gontentful.Fields)
+	for name, v := range res.Fields {
+		values, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, code := range s.fallbacks(locale) {
+			if lv, ok := values[code]; ok {
+				fields[name] = lv
+				break
+			}
+		}
+	}
+	sys := *res.Sys
+	sys.Locale = locale
+	res.Sys = &sys
+	res.Fields = fields
+	res.Locale = locale
+	return res
+}
+
+// fallbacks returns the locale followed by its fallback chain
+func (s *Server) fallbacks(locale string) []string {
+	codes := []string{locale}
+	seen := map[string]bool{locale: true}
+	for {
+		next := ""
+		for _, l := range s.locales {
+			if l.Code == codes[len(codes)-1] {
+				next = l.FallbackCode
+			}
+		}
+		if next == "" || seen[next] {
+			break
+		}
+		seen[next] = true
+		codes = append(codes, next)
+	}
+	return codes
+}
+
+func (s *Server) listEntities(w http.ResponseWriter, r *http.Request, api string, store map[string]*entity) {
+	query := r.URL.Query()
+
+	skip, _ := strconv.Atoi(query.Get("skip"))
+	limit := defaultLimit
+	if l := query.Get("limit"); l != "" {
+		limit, _ = strconv.Atoi(l)
+	}
+	if limit < 0 || limit > maxLimit || skip < 0 {
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("The limit parameter should be between 0 and %d", maxLimit))
+		return
+	}
+
+	matches := make([]*entity, 0)
+	for id := range store {
+		e := s.visible(store, api, id)
+		if e != nil && s.match(e, query) {
+			matches = append(matches, e)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].order < matches[j].order
+	})
+
+	res := &gontentful.Entries{
+		Sys:   &gontentful.Sys{Type: "Array"},
+		Total: len(matches),
+		Skip:  skip,
+		Limit: limit,
+		Items: make([]*gontentful.Entry, 0),
+	}
+	if skip < len(matches) {
+		end := skip + limit
+		if end > len(matches) {
+			end = len(matches)
+		}
+		for _, e := range matches[skip:end] {
+			res.Items = append(res.Items, s.localized(e, api, query.Get("locale")))
+		}
+	}
+
+	if api != apiManagement {
+		include := 1
+		if i := query.Get("include"); i != "" {
+			include, _ = strconv.Atoi(i)
+		}
+		res.Includes = s.includes(res.Items, api, query.Get("locale"), include)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// match supports the content_type, sys.id, sys.id[in] and fields.<id> equality filters
+func (s *Server) match(e *entity, query map[string][]string) bool {
+	for key, values := range query {
+		value := values[0]
+		switch {
+		case key == "content_type":
+			if e.sys.ContentType == nil || e.sys.ContentType.Sys.ID != value {
+				return false
+			}
+		case key == "sys.id":
+			if e.sys.ID != value {
+				return false
+			}
+		case key == "sys.id[in]":
+			if !contains(strings.Split(value, ","), e.sys.ID) {
+				return false
+			}
+		case strings.HasPrefix(key, "fields.") && !strings.Contains(key, "["):
+			values, _ := e.fields[strings.TrimPrefix(key, "fields.")].(map[string]interface{})
+			if !matchValue(values[s.defaultLocale()], value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchValue(v interface{}, value string) bool {
+	if arr, ok := v.([]interface{}); ok {
+		for _, a := range arr {
+			if fmt.Sprint(a) == value {
+				return true
+			}
+		}
+		return false
+	}
+	return v != nil && fmt.Sprint(v) == value
+}
+
+// includes collects the entries and assets linked from items up to depth levels
+func (s *Server) includes(items []*gontentful.Entry, api string, locale string, depth int) *gontentful.Include {
+	inc := &gontentful.Include{}
+	seen := make(map[string]bool)
+	for _, item := range items {
+		seen[item.Sys.ID] = true
+	}
+
+	level := items
+	for d := 0; d < depth && len(level) > 0; d++ {
+		next := make([]*gontentful.Entry, 0)
+		for _, item := range level {
+			for _, l := range collectLinks(item.Fields) {
+				if seen[l.id] {
+					continue
+				}
+				store := s.entries
+				if l.linkType == gontentful.ASSET {
+					store = s.assets
+				}
+				e := s.visible(store, api, l.id)
+				if e == nil {
+					continue
+				}
+				seen[l.id] = true
+				le := s.localized(e, api, locale)
+				if l.linkType == gontentful.ASSET {
+					inc.Asset = append(inc.Asset, le)
+				} else {
+					inc.Entry = append(inc.Entry, le)
+				}
+				next = append(next, le)
+			}
+		}
+		level = next
+	}
+
+	if len(inc.Entry) == 0 && len(inc.Asset) == 0 {
+		return nil
+	}
+	return inc
+}
+
+type link struct {
+	linkType string
+	id       string
+}
+
+func collectLinks(v interface{}) []link {
+	links := make([]link, 0)
+	switch t := v.(type) {
+	case gontentful.Fields:
+		for _, fv := range t {
+			links = append(links, collectLinks(fv)...)
+		}
+	case map[string]interface{}:
+		if sys, ok := t["sys"].(map[string]interface{}); ok && sys["type"] == "Link" {
+			lt, _ := sys["linkType"].(string)
+			id, _ := sys["id"].(string)
+			return append(links, link{linkType: lt, id: id})
+		}
+		for _, fv := range t {
+			links = append(links, collectLinks(fv)...)
+		}
+	case []interface{}:
+		for _, fv := range t {
+			links = append(links, collectLinks(fv)...)
+		}
+	}
+	return links
+}
+
+// checkVersion writes a 409 if the version header does not match the entity,
+// a missing header is only accepted when the version is not required
+func checkVersion(w http.ResponseWriter, r *http.Request, sys *gontentful.Sys, required bool) bool {
+	v := r.Header.Get(headerVersion)
+	if v == "" && !required {
+		return true
+	}
+	if v != strconv.Itoa(sys.Version) {
+		versionMismatch(w)
+		return false
+	}
+	return true
+}
+
+func sysID(e *gontentful.Entry) string {
+	if e.Sys != nil {
+		return e.Sys.ID
+	}
+	return ""
+}
+
+func contentTypeID(e *gontentful.Entry) string {
+	if e.Sys != nil && e.Sys.ContentType != nil && e.Sys.ContentType.Sys != nil {
+		return e.Sys.ContentType.Sys.ID
+	}
+	return ""
+}
+
+func contentTypeLink(id string) *gontentful.ContentType {
+	return &gontentful.ContentType{
+		Sys: &gontentful.Sys{
+			ID:       id,
+			Type:     "Link",
+			LinkType: gontentful.CONTENT_TYPE,
+		},
+	}
+}
+
+func copyFields(fields gontentful.Fields) gontentful.Fields {
+	res := make(gontentful.Fields)
+	if fields == nil {
+		return res
+	}
+	b, _ := json.Marshal(fields)
+	json.Unmarshal(b, &res)
+	return res
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
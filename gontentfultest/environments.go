@@ -0,0 +1,218 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+// environment is tracked for the environments api, only EnvironmentID serves content
+type environment struct {
+	order   int
+	name    string
+	sys     *gontentful.Sys
+	readyAt time.Time
+}
+
+type alias struct {
+	sys    *gontentful.Sys
+	target string
+}
+
+// AddEnvironmentAlias creates an alias pointing at an environment, aliases of
+// EnvironmentID serve its content
+func (s *Server) AddEnvironmentAlias(id string, environmentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.aliases[id] = &alias{
+		sys:    &gontentful.Sys{ID: id, Type: gontentful.ENVIRONMENT_ALIAS, Version: 1, CreatedAt: now()},
+		target: environmentID,
+	}
+}
+
+// servesContent reports whether the environment or alias id is EnvironmentID
+func (s *Server) servesContent(id string) bool {
+	if id == s.EnvironmentID {
+		return true
+	}
+	a := s.aliases[id]
+	return a != nil && a.target == s.EnvironmentID
+}
+
+func (s *Server) ensureEnvironment() {
+	if _, ok := s.environments[s.EnvironmentID]; !ok {
+		s.putEnvironment(s.EnvironmentID, s.EnvironmentID, 0)
+	}
+}
+
+func (s *Server) putEnvironment(id string, name string, delay time.Duration) *environment {
+	s.seq++
+	env := &environment{
+		order:   s.seq,
+		name:    name,
+		sys:     &gontentful.Sys{ID: id, Type: gontentful.ENVIRONMENT, Version: 1, CreatedAt: now(), UpdatedAt: now()},
+		readyAt: time.Now().Add(delay),
+	}
+	s.environments[id] = env
+	return env
+}
+
+func (s *Server) environment(env *environment) *gontentful.Environment {
+	status := gontentful.EnvironmentStatusReady
+	if time.Now().Before(env.readyAt) {
+		status = gontentful.EnvironmentStatusQueued
+	}
+	sys := &gontentful.EnvironmentSys{
+		Sys:    *env.sys,
+		Status: &gontentful.Entry{Sys: &gontentful.Sys{Type: "Link", LinkType: "Status", ID: status}},
+	}
+	for _, a := range s.aliases {
+		if a.target == env.sys.ID {
+			sys.Aliases = append(sys.Aliases, &gontentful.Entry{Sys: &gontentful.Sys{Type: "Link", LinkType: gontentful.ENVIRONMENT_ALIAS, ID: a.sys.ID}})
+		}
+	}
+	return &gontentful.Environment{Sys: sys, Name: env.name}
+}
+
+func (s *Server) serveEnvironments(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement {
+		notFound(w)
+		return
+	}
+	s.ensureEnvironment()
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		envs := make([]*environment, 0, len(s.environments))
+		for _, env := range s.environments {
+			envs = append(envs, env)
+		}
+		sort.Slice(envs, func(i, j int) bool { return envs[i].order < envs[j].order })
+		res := &gontentful.Environments{
+			Sys:   &gontentful.Sys{Type: "Array"},
+			Total: len(envs),
+			Limit: len(envs),
+			Items: make([]*gontentful.Environment, 0, len(envs)),
+		}
+		for _, env := range envs {
+			res.Items = append(res.Items, s.environment(env))
+		}
+		writeJSON(w, http.StatusOK, res)
+		return
+	}
+
+	id := rest[0]
+	env := s.environments[id]
+	switch r.Method {
+	case http.MethodGet:
+		if env == nil {
+			notFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, s.environment(env))
+	case http.MethodPut:
+		if env != nil {
+			writeError(w, http.StatusConflict, "VersionMismatch", "Environment already exists")
+			return
+		}
+		source := r.Header.Get(headerSourceEnvironment)
+		if source == "" {
+			source = DefaultEnvironmentID
+		}
+		if _, ok := s.environments[source]; !ok {
+			writeError(w, http.StatusNotFound, "NotFound", "Source environment not found")
+			return
+		}
+		body := &gontentful.Environment{}
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return
+		}
+		env = s.putEnvironment(id, body.Name, s.ProcessingDelay)
+		writeJSON(w, http.StatusCreated, s.environment(env))
+	case http.MethodDelete:
+		if env == nil {
+			notFound(w)
+			return
+		}
+		delete(s.environments, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) serveEnvironmentAliases(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement {
+		notFound(w)
+		return
+	}
+	s.ensureEnvironment()
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		ids := make([]string, 0, len(s.aliases))
+		for id := range s.aliases {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		res := &gontentful.EnvironmentAliases{
+			Sys:   &gontentful.Sys{Type: "Array"},
+			Total: len(ids),
+			Limit: len(ids),
+			Items: make([]*gontentful.EnvironmentAlias, 0, len(ids)),
+		}
+		for _, id := range ids {
+			res.Items = append(res.Items, s.aliases[id].alias())
+		}
+		writeJSON(w, http.StatusOK, res)
+		return
+	}
+
+	a := s.aliases[rest[0]]
+	if a == nil {
+		notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, a.alias())
+	case http.MethodPut:
+		if !checkVersion(w, r, a.sys, true) {
+			return
+		}
+		body := &gontentful.EnvironmentAlias{}
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil || body.Environment == nil || body.Environment.Sys == nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Missing environment link")
+			return
+		}
+		if _, ok := s.environments[body.Environment.Sys.ID]; !ok {
+			validationFailed(w, "Environment not found")
+			return
+		}
+		a.target = body.Environment.Sys.ID
+		a.sys.Version++
+		a.sys.UpdatedAt = now()
+		writeJSON(w, http.StatusOK, a.alias())
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (a *alias) alias() *gontentful.EnvironmentAlias {
+	sys := *a.sys
+	return &gontentful.EnvironmentAlias{
+		Sys:         &sys,
+		Environment: &gontentful.Entry{Sys: &gontentful.Sys{Type: "Link", LinkType: gontentful.ENVIRONMENT, ID: a.target}},
+	}
+}
@@ -0,0 +1,163 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func (s *Server) serveLocales(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	for _, l := range s.locales {
+		s.localeSys(l)
+	}
+
+	if len(rest) == 0 {
+		switch {
+		case r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, &gontentful.Locales{
+				Total: len(s.locales),
+				Limit: len(s.locales),
+				Items: s.locales,
+			})
+		case r.Method == http.MethodPost && api == apiManagement:
+			body := &gontentful.Locale{}
+			if !decodeLocale(w, r, body) {
+				return
+			}
+			if s.locale(body.Code) != nil {
+				validationFailed(w, fmt.Sprintf("Locale %s already exists", body.Code))
+				return
+			}
+			if !s.validFallback(w, body) {
+				return
+			}
+			body.Default = false
+			body.Sys = nil
+			s.localeSys(body)
+			s.locales = append(s.locales, body)
+			writeJSON(w, http.StatusCreated, body)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	if api != apiManagement {
+		notFound(w)
+		return
+	}
+	idx := -1
+	for i, l := range s.locales {
+		if l.Sys.ID == rest[0] {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		notFound(w)
+		return
+	}
+	l := s.locales[idx]
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, l)
+	case http.MethodPut:
+		if !checkVersion(w, r, l.Sys, true) {
+			return
+		}
+		body := &gontentful.Locale{}
+		if !decodeLocale(w, r, body) {
+			return
+		}
+		if other := s.locale(body.Code); other != nil && other != l {
+			validationFailed(w, fmt.Sprintf("Locale %s already exists", body.Code))
+			return
+		}
+		if body.FallbackCode == body.Code {
+			validationFailed(w, "A locale cannot fall back to itself")
+			return
+		}
+		if !s.validFallback(w, body) {
+			return
+		}
+		l.Code = body.Code
+		l.Name = body.Name
+		l.FallbackCode = body.FallbackCode
+		l.Optional = body.Optional
+		l.ContentDeliveryAPI = body.ContentDeliveryAPI
+		l.ContentManagementAPI = body.ContentManagementAPI
+		localeDefaults(l)
+		l.Sys.Version++
+		l.Sys.UpdatedAt = now()
+		writeJSON(w, http.StatusOK, l)
+	case http.MethodDelete:
+		if l.Default {
+			validationFailed(w, "Cannot delete the default locale")
+			return
+		}
+		for _, other := range s.locales {
+			if other.FallbackCode == l.Code {
+				validationFailed(w, fmt.Sprintf("Locale %s falls back to %s", other.Code, l.Code))
+				return
+			}
+		}
+		s.locales = append(s.locales[:idx], s.locales[idx+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func decodeLocale(w http.ResponseWriter, r *http.Request, body *gontentful.Locale) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return false
+	}
+	if body.Code == "" || body.Name == "" {
+		validationFailed(w, "Code and name are required")
+		return false
+	}
+	return true
+}
+
+// validFallback rejects fallback codes of locales that do not exist
+func (s *Server) validFallback(w http.ResponseWriter, body *gontentful.Locale) bool {
+	if body.FallbackCode != "" && s.locale(body.FallbackCode) == nil {
+		validationFailed(w, fmt.Sprintf("Unknown fallback code %s", body.FallbackCode))
+		return false
+	}
+	return true
+}
+
+func (s *Server) locale(code string) *gontentful.Locale {
+	for _, l := range s.locales {
+		if l.Code == code {
+			return l
+		}
+	}
+	return nil
+}
+
+// localeSys gives locales set up without sys (SetLocales) an id and version
+func (s *Server) localeSys(l *gontentful.Locale) {
+	if l.Sys != nil {
+		return
+	}
+	s.seq++
+	l.Sys = &gontentful.Sys{ID: fmt.Sprintf("locale%d", s.seq), Type: gontentful.LOCALE, Version: 1, CreatedAt: now()}
+	localeDefaults(l)
+}
+
+// localeDefaults enables locales on the delivery and management apis unless disabled, like Contentful
+func localeDefaults(l *gontentful.Locale) {
+	if l.ContentDeliveryAPI == nil {
+		cda := true
+		l.ContentDeliveryAPI = &cda
+	}
+	if l.ContentManagementAPI == nil {
+		cma := true
+		l.ContentManagementAPI = &cma
+	}
+}
@@ -0,0 +1,247 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/moonwalker/gontentful"
+)
+
+// releaseAction is run when created, the first read still reports it in progress
+type releaseAction struct {
+	action *gontentful.ReleaseAction
+	read   bool
+}
+
+func (s *Server) serveReleases(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement {
+		notFound(w)
+		return
+	}
+
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			items := make([]*gontentful.Release, 0, len(s.releases))
+			for _, id := range s.releaseIDs {
+				items = append(items, s.releases[id])
+			}
+			writeJSON(w, http.StatusOK, &gontentful.Releases{
+				Sys:   &gontentful.Sys{Type: "Array"},
+				Limit: maxLimit,
+				Items: items,
+			})
+		case http.MethodPost:
+			body, ok := decodeRelease(w, r)
+			if !ok {
+				return
+			}
+			at := now()
+			body.Sys = &gontentful.Sys{
+				ID:        s.nextID("release"),
+				Type:      gontentful.RELEASE,
+				Version:   1,
+				CreatedAt: at,
+				UpdatedAt: at,
+			}
+			s.releases[body.Sys.ID] = body
+			s.releaseIDs = append(s.releaseIDs, body.Sys.ID)
+			writeJSON(w, http.StatusCreated, body)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	rel := s.releases[rest[0]]
+	if rel == nil {
+		notFound(w)
+		return
+	}
+
+	switch {
+	case len(rest) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, rel)
+		case http.MethodPut:
+			if !checkVersion(w, r, rel.Sys, true) {
+				return
+			}
+			body, ok := decodeRelease(w, r)
+			if !ok {
+				return
+			}
+			rel.Title, rel.Entities = body.Title, body.Entities
+			rel.Sys.Version++
+			rel.Sys.UpdatedAt = now()
+			writeJSON(w, http.StatusOK, rel)
+		case http.MethodDelete:
+			delete(s.releases, rel.Sys.ID)
+			for i, id := range s.releaseIDs {
+				if id == rel.Sys.ID {
+					s.releaseIDs = append(s.releaseIDs[:i], s.releaseIDs[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+	case len(rest) == 2 && rest[1] == "published":
+		action := gontentful.ReleaseActionPublish
+		switch r.Method {
+		case http.MethodPut:
+		case http.MethodDelete:
+			action = gontentful.ReleaseActionUnpublish
+		default:
+			methodNotAllowed(w)
+			return
+		}
+		if !checkVersion(w, r, rel.Sys, true) {
+			return
+		}
+		s.startReleaseAction(w, rel, action)
+	case len(rest) == 2 && rest[1] == "validate":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		s.startReleaseAction(w, rel, gontentful.ReleaseActionValidate)
+	case len(rest) == 3 && rest[1] == "actions":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		a := s.releaseActions[rest[2]]
+		if a == nil || a.action.Sys.Release.Sys.ID != rel.Sys.ID {
+			notFound(w)
+			return
+		}
+		if !a.read {
+			a.read = true
+			writeJSON(w, http.StatusOK, releaseInProgress(a.action))
+			return
+		}
+		writeJSON(w, http.StatusOK, a.action)
+	default:
+		notFound(w)
+	}
+}
+
+// serveReleaseActions lists the release actions, sys.release.sys.id[in] filters them by release
+func (s *Server) serveReleaseActions(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement || len(rest) != 0 {
+		notFound(w)
+		return
+	}
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	releases := r.URL.Query().Get("sys.release.sys.id[in]")
+	items := make([]*gontentful.ReleaseAction, 0)
+	for _, id := range s.releaseActionIDs {
+		a := s.releaseActions[id].action
+		if releases != "" && !contains(strings.Split(releases, ","), a.Sys.Release.Sys.ID) {
+			continue
+		}
+		items = append(items, a)
+	}
+	writeJSON(w, http.StatusOK, &gontentful.ReleaseActions{
+		Sys:   &gontentful.Sys{Type: "Array"},
+		Limit: maxLimit,
+		Items: items,
+	})
+}
+
+func decodeRelease(w http.ResponseWriter, r *http.Request) (*gontentful.Release, bool) {
+	body := &gontentful.Release{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil || body.Title == "" || body.Entities == nil {
+		validationFailed(w, "title and entities are required")
+		return nil, false
+	}
+	if len(body.Entities.Items) > gontentful.MaxBulkItems {
+		validationFailed(w, "too many entities")
+		return nil, false
+	}
+	return body, true
+}
+
+func (s *Server) startReleaseAction(w http.ResponseWriter, rel *gontentful.Release, action string) {
+	at := now()
+	a := &gontentful.ReleaseAction{
+		Sys: &gontentful.ReleaseActionSys{
+			Sys: gontentful.Sys{
+				ID:        s.nextID("releaseAction"),
+				Type:      gontentful.RELEASE_ACTION,
+				CreatedAt: at,
+				UpdatedAt: at,
+			},
+			Status: gontentful.ReleaseActionStatusSucceeded,
+			Release: &gontentful.Entry{
+				Sys: &gontentful.Sys{Type: gontentful.LINK, LinkType: gontentful.RELEASE, ID: rel.Sys.ID},
+			},
+		},
+		Action: action,
+	}
+	s.runReleaseAction(a, rel)
+	s.releaseActions[a.Sys.ID] = &releaseAction{action: a}
+	s.releaseActionIDs = append(s.releaseActionIDs, a.Sys.ID)
+	writeJSON(w, http.StatusAccepted, releaseInProgress(a))
+}
+
+// runReleaseAction applies the action to every entity or to none of them, releases publish
+// the current versions so the links carry no version
+func (s *Server) runReleaseAction(a *gontentful.ReleaseAction, rel *gontentful.Release) {
+	targets := make([]*entity, 0, len(rel.Entities.Items))
+	errs := make([]*gontentful.BulkEntityError, 0)
+	for _, link := range rel.Entities.Items {
+		if link == nil || link.Sys == nil {
+			continue
+		}
+		sys := *link.Sys
+		if e := s.linked(sys.LinkType, sys.ID); e != nil {
+			sys.Version = e.sys.Version
+		}
+		e, msg := s.bulkTarget(a.Action, &sys)
+		if msg != "" {
+			errs = append(errs, &gontentful.BulkEntityError{
+				Error:  &gontentful.ActionError{Sys: &gontentful.Sys{Type: "Error", ID: msg}},
+				Entity: link,
+			})
+			continue
+		}
+		targets = append(targets, e)
+	}
+
+	if len(errs) > 0 {
+		details, _ := json.Marshal(map[string]interface{}{"errors": errs})
+		a.Sys.Status = gontentful.ReleaseActionStatusFailed
+		a.Error = &gontentful.ActionError{
+			Sys:     &gontentful.Sys{Type: "Error", ID: "InvalidEntry"},
+			Message: strconv.Itoa(len(errs)) + " of the entities failed",
+			Details: details,
+		}
+		return
+	}
+	for _, e := range targets {
+		switch a.Action {
+		case gontentful.ReleaseActionPublish:
+			s.publish(e)
+		case gontentful.ReleaseActionUnpublish:
+			s.unpublish(e)
+		}
+	}
+}
+
+func releaseInProgress(a *gontentful.ReleaseAction) *gontentful.ReleaseAction {
+	res := *a
+	sys := *a.Sys
+	sys.Status = gontentful.ReleaseActionStatusInProgress
+	res.Sys = &sys
+	res.Error = nil
+	return &res
+}
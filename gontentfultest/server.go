@@ -0,0 +1,427 @@
+// Package gontentfultest provides an in-process fake of the Contentful
+// delivery, preview and management APIs for testing code built on gontentful.
+//
+// The fake serves the delivery API under /cda, the preview API under /preview
+// and the management API under /cma of the same httptest.Server, Client and
+// ClientOptions wire those base urls up:
+//
+//	srv := gontentfultest.NewServer()
+//	defer srv.Close()
+//	srv.AddEntry(entry)
+//	res, err := srv.Client().Spaces.Sync("")
+package gontentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+const (
+	DefaultSpaceID       = "space"
+	DefaultEnvironmentID = "master"
+	DefaultPageSize      = 100
+
+	apiDelivery   = "cda"
+	apiPreview    = "preview"
+	apiManagement = "cma"
+
+	headerVersion           = "X-Contentful-Version"
+	headerContentType       = "X-Contentful-Content-Type"
+	headerRequestID         = "X-Contentful-Request-Id"
+	headerReset             = "X-Contentful-RateLimit-Reset"
+	headerSourceEnvironment = "X-Contentful-Source-Environment"
+)
+
+// Server is a fake Contentful space backed by an httptest.Server.
+// SpaceID, EnvironmentID, PageSize and ProcessingDelay can be changed
+// before the first request is made.
+type Server struct {
+	*httptest.Server
+
+	SpaceID       string
+	EnvironmentID string
+	// PageSize is the number of items per sync page, DefaultPageSize when 0
+	PageSize int
+	// ProcessingDelay is how long assets take to process after the process call
+	// and new environments stay queued
+	ProcessingDelay time.Duration
+
+	mu               sync.Mutex
+	seq              int
+	locales          []*gontentful.Locale
+	contentTypes     map[string]*contentType
+	entries          map[string]*entity
+	assets           map[string]*entity
+	uploads          map[string][]byte
+	environments     map[string]*environment
+	aliases          map[string]*alias
+	bulkActions      map[string]*bulkAction
+	releases         map[string]*gontentful.Release
+	releaseIDs       []string
+	releaseActions   map[string]*releaseAction
+	releaseActionIDs []string
+	webhooks         map[string]*gontentful.Webhook
+	webhookIDs       []string
+	events           []*gontentful.Entry
+	failures         []*Failure
+	requests         []string
+}
+
+// Failure is an error response injected in place of the next Times matching requests
+type Failure struct {
+	// Method and Path (a substring of the request path) select the requests
+	// to fail, empty values match every request
+	Method  string
+	Path    string
+	Status  int
+	ErrorID string
+	Message string
+	Header  http.Header
+	Times   int
+}
+
+func NewServer() *Server {
+	s := &Server{
+		SpaceID:       DefaultSpaceID,
+		EnvironmentID: DefaultEnvironmentID,
+		PageSize:      DefaultPageSize,
+		locales: []*gontentful.Locale{
+			{Code: gontentful.DefaultLocale, Name: "English", Default: true},
+		},
+		contentTypes:   make(map[string]*contentType),
+		entries:        make(map[string]*entity),
+		assets:         make(map[string]*entity),
+		uploads:        make(map[string][]byte),
+		environments:   make(map[string]*environment),
+		aliases:        make(map[string]*alias),
+		bulkActions:    make(map[string]*bulkAction),
+		releases:       make(map[string]*gontentful.Release),
+		releaseActions: make(map[string]*releaseAction),
+		webhooks:       make(map[string]*gontentful.Webhook),
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// ClientOptions returns options pointing every API of a gontentful.Client at the fake
+func (s *Server) ClientOptions() *gontentful.ClientOptions {
+	return &gontentful.ClientOptions{
+		SpaceID:       s.SpaceID,
+		EnvironmentID: s.EnvironmentID,
+		CdnToken:      "cdn-token",
+		PreviewToken:  "preview-token",
+		CmaToken:      "cma-token",
+		CdnURL:        s.URL + "/" + apiDelivery,
+		PreviewURL:    s.URL + "/" + apiPreview,
+		CmaURL:        s.URL + "/" + apiManagement,
+		HTTPClient:    s.Server.Client(),
+	}
+}
+
+func (s *Server) Client() *gontentful.Client {
+	return gontentful.NewClient(s.ClientOptions())
+}
+
+// SetLocales replaces the locales of the space, the first default locale is used for fallbacks
+func (s *Server) SetLocales(locales ...*gontentful.Locale) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locales = locales
+}
+
+// AddContentType stores and publishes a content type
+func (s *Server) AddContentType(ct *gontentful.ContentType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.putContentType(ct.Sys.ID, ct)
+	c.publish(now())
+}
+
+// AddEntry stores and publishes an entry, its fields are in the management (all locales) format
+func (s *Server) AddEntry(e *gontentful.Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ent := s.create(s.entries, gontentful.ENTRY, sysID(e), contentTypeID(e), e.Fields)
+	s.publish(ent)
+}
+
+// AddAsset stores and publishes an asset, its fields are in the management (all locales) format
+func (s *Server) AddAsset(a *gontentful.Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ent := s.create(s.assets, gontentful.ASSET, sysID(a), "", a.Fields)
+	s.publish(ent)
+}
+
+// Entry returns the current management state of an entry or nil
+func (s *Server) Entry(id string) *gontentful.Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e := s.entries[id]; e != nil {
+		return e.management()
+	}
+	return nil
+}
+
+// Asset returns the current management state of an asset or nil
+func (s *Server) Asset(id string) *gontentful.Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a := s.assets[id]; a != nil {
+		s.settle(a)
+		return a.management()
+	}
+	return nil
+}
+
+// Inject queues a failure, a zero Times fails one request
+func (s *Server) Inject(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f.Times <= 0 {
+		f.Times = 1
+	}
+	s.failures = append(s.failures, &f)
+}
+
+// InjectRateLimit answers the next requests with 429 and a zero reset header
+func (s *Server) InjectRateLimit(times int) {
+	s.Inject(Failure{
+		Status:  http.StatusTooManyRequests,
+		ErrorID: "RateLimitExceeded",
+		Message: "You have exceeded the rate limit of the Organization this Space belongs to.",
+		Header:  http.Header{headerReset: []string{"0"}},
+		Times:   times,
+	})
+}
+
+// InjectVersionMismatch answers the next requests on path with 409
+func (s *Server) InjectVersionMismatch(method string, path string, times int) {
+	s.Inject(Failure{
+		Method:  method,
+		Path:    path,
+		Status:  http.StatusConflict,
+		ErrorID: "VersionMismatch",
+		Message: "",
+		Times:   times,
+	})
+}
+
+// Requests returns every request received so far as "METHOD path"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set(headerRequestID, fmt.Sprintf("req-%d", len(s.requests)))
+
+	if f := s.failure(r); f != nil {
+		for k, v := range f.Header {
+			w.Header()[k] = v
+		}
+		writeError(w, f.Status, f.ErrorID, f.Message)
+		return
+	}
+
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segs) < 3 || segs[1] != "spaces" {
+		notFound(w)
+		return
+	}
+	api := segs[0]
+	if api != apiDelivery && api != apiPreview && api != apiManagement {
+		notFound(w)
+		return
+	}
+	if segs[2] != s.SpaceID {
+		notFound(w)
+		return
+	}
+
+	rest := segs[3:]
+	if len(rest) >= 1 && rest[0] == "environment_aliases" {
+		s.serveEnvironmentAliases(w, r, api, rest[1:])
+		return
+	}
+	if len(rest) <= 2 && len(rest) >= 1 && rest[0] == "environments" {
+		s.serveEnvironments(w, r, api, rest[1:])
+		return
+	}
+	if len(rest) >= 2 && rest[0] == "environments" {
+		if !s.servesContent(rest[1]) {
+			notFound(w)
+			return
+		}
+		rest = rest[2:]
+	}
+
+	if len(rest) == 0 {
+		s.serveSpace(w, r)
+		return
+	}
+
+	switch rest[0] {
+	case "entries":
+		s.serveEntities(w, r, api, s.entries, gontentful.ENTRY, rest[1:])
+	case "assets":
+		s.serveEntities(w, r, api, s.assets, gontentful.ASSET, rest[1:])
+	case "content_types":
+		s.serveContentTypes(w, r, api, rest[1:])
+	case "locales":
+		s.serveLocales(w, r, api, rest[1:])
+	case "sync":
+		s.serveSync(w, r, api)
+	case "uploads":
+		s.serveUploads(w, r, api, rest[1:])
+	case "bulk_actions":
+		s.serveBulkActions(w, r, api, rest[1:])
+	case "releases":
+		s.serveReleases(w, r, api, rest[1:])
+	case "release_actions":
+		s.serveReleaseActions(w, r, api, rest[1:])
+	case "webhook_definitions":
+		s.serveWebhooks(w, r, api, rest[1:])
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) failure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" && !strings.Contains(r.URL.Path, f.Path) {
+			continue
+		}
+		f.Times--
+		if f.Times <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) serveSpace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, &gontentful.Space{
+		Sys:     &gontentful.Sys{ID: s.SpaceID, Type: "Space"},
+		Name:    s.SpaceID,
+		Locales: s.locales,
+	})
+}
+
+func (s *Server) serveUploads(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement {
+		notFound(w)
+		return
+	}
+	if len(rest) > 0 {
+		if _, ok := s.uploads[rest[0]]; !ok || r.Method != http.MethodGet {
+			notFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, uploadEntry(rest[0]))
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	id := s.nextID("upload")
+	s.uploads[id] = data
+	writeJSON(w, http.StatusCreated, uploadEntry(id))
+}
+
+func (s *Server) defaultLocale() string {
+	for _, l := range s.locales {
+		if l.Default {
+			return l.Code
+		}
+	}
+	return gontentful.DefaultLocale
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s%d", prefix, s.seq)
+}
+
+func uploadEntry(id string) *gontentful.Entry {
+	return &gontentful.Entry{
+		Sys: &gontentful.Sys{ID: id, Type: "Upload"},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.contentful.delivery.v1+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, id string, message string) {
+	writeJSON(w, status, &gontentful.ErrorResponse{
+		Sys:       &gontentful.Sys{Type: "Error", ID: id},
+		Message:   message,
+		RequestID: w.Header().Get(headerRequestID),
+	})
+}
+
+// validationFailed answers 422 with the message as the single validation error, like Contentful
+func validationFailed(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, &gontentful.ErrorResponse{
+		Sys:       &gontentful.Sys{Type: "Error", ID: "ValidationFailed"},
+		Message:   "Validation error",
+		RequestID: w.Header().Get(headerRequestID),
+		Details: &gontentful.ErrorDetails{
+			Errors: []*gontentful.ErrorDetail{{Name: "invalid", Details: message}},
+		},
+	})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "NotFound", "The resource could not be found.")
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, "BadRequest", "Method not allowed.")
+}
+
+func versionMismatch(w http.ResponseWriter) {
+	writeError(w, http.StatusConflict, "VersionMismatch", "")
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
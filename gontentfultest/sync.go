@@ -0,0 +1,126 @@
+package gontentfultest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/moonwalker/gontentful"
+)
+
+// syncPage identifies a page of the changes between two positions of the
+// publish event log, initial syncs only contain the live state
+type syncPage struct {
+	initial bool
+	since   int
+	upto    int
+	offset  int
+}
+
+func (p *syncPage) token() string {
+	initial := 0
+	if p.initial {
+		initial = 1
+	}
+	return fmt.Sprintf("p%d_%d_%d_%d", initial, p.since, p.upto, p.offset)
+}
+
+func parseSyncToken(token string) (*syncPage, error) {
+	p := &syncPage{}
+	initial := 0
+	if _, err := fmt.Sscanf(token, "p%d_%d_%d_%d", &initial, &p.since, &p.upto, &p.offset); err == nil {
+		p.initial = initial == 1
+		return p, nil
+	}
+	if _, err := fmt.Sscanf(token, "s%d", &p.since); err != nil {
+		return nil, fmt.Errorf("invalid sync token: %s", token)
+	}
+	p.upto = -1
+	return p, nil
+}
+
+func (s *Server) serveSync(w http.ResponseWriter, r *http.Request, api string) {
+	if api == apiManagement || r.Method != http.MethodGet {
+		notFound(w)
+		return
+	}
+
+	query := r.URL.Query()
+	var page *syncPage
+	if query.Get("initial") == "true" {
+		page = &syncPage{initial: true, upto: -1}
+	} else {
+		var err error
+		page, err = parseSyncToken(query.Get("sync_token"))
+		if err != nil || page.since > len(s.events) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Invalid sync token")
+			return
+		}
+	}
+	if page.upto < 0 {
+		page.upto = len(s.events)
+	}
+
+	items := s.syncItems(page)
+	res := &gontentful.SyncResponse{
+		Sys:   &gontentful.Sys{Type: "Array"},
+		Items: make([]*gontentful.Entry, 0),
+	}
+
+	pageSize := s.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	end := page.offset + pageSize
+	if end < len(items) {
+		res.Items = items[page.offset:end]
+		next := *page
+		next.offset = end
+		res.NextPageURL = s.syncURL(r, next.token())
+	} else {
+		if page.offset < len(items) {
+			res.Items = items[page.offset:]
+		}
+		res.NextSyncURL = s.syncURL(r, fmt.Sprintf("s%d", page.upto))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// syncItems returns the latest event of every item changed in the page range
+func (s *Server) syncItems(page *syncPage) []*gontentful.Entry {
+	latest := make(map[string]int)
+	for i := page.since; i < page.upto; i++ {
+		latest[syncKey(s.events[i])] = i
+	}
+
+	items := make([]*gontentful.Entry, 0)
+	for i := page.since; i < page.upto; i++ {
+		e := s.events[i]
+		if latest[syncKey(e)] != i {
+			continue
+		}
+		if page.initial && e.Sys.Type != gontentful.ENTRY && e.Sys.Type != gontentful.ASSET {
+			continue
+		}
+		items = append(items, e)
+	}
+	return items
+}
+
+// syncKey identifies the item of an event, entries and assets may share ids
+func syncKey(e *gontentful.Entry) string {
+	switch e.Sys.Type {
+	case gontentful.DELETED_ENTRY:
+		return gontentful.ENTRY + ":" + e.Sys.ID
+	case gontentful.DELETED_ASSET:
+		return gontentful.ASSET + ":" + e.Sys.ID
+	}
+	return e.Sys.Type + ":" + e.Sys.ID
+}
+
+func (s *Server) syncURL(r *http.Request, token string) string {
+	q := url.Values{}
+	q.Set("sync_token", token)
+	return s.URL + r.URL.Path + "?" + q.Encode()
+}
@@ -0,0 +1,135 @@
+package gontentfultest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/moonwalker/gontentful"
+)
+
+// maxWebhooksLimit is the largest page of webhook definitions, like Contentful
+const maxWebhooksLimit = 100
+
+// AddWebhook stores a webhook definition, a missing id is generated
+func (s *Server) AddWebhook(wh *gontentful.Webhook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putWebhook(wh)
+}
+
+func (s *Server) putWebhook(wh *gontentful.Webhook) *gontentful.Webhook {
+	res := *wh
+	at := now()
+	res.Sys = &gontentful.Sys{
+		Type:      gontentful.WEBHOOK,
+		Version:   1,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+	if wh.Sys != nil && wh.Sys.ID != "" {
+		res.Sys.ID = wh.Sys.ID
+	} else {
+		res.Sys.ID = s.nextID("webhook")
+	}
+	if _, ok := s.webhooks[res.Sys.ID]; !ok {
+		s.webhookIDs = append(s.webhookIDs, res.Sys.ID)
+	}
+	s.webhooks[res.Sys.ID] = &res
+	return &res
+}
+
+func (s *Server) serveWebhooks(w http.ResponseWriter, r *http.Request, api string, rest []string) {
+	if api != apiManagement || len(rest) > 1 {
+		notFound(w)
+		return
+	}
+
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listWebhooks(w, r)
+		case http.MethodPost:
+			body := &gontentful.Webhook{}
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil || body.Name == "" || body.URL == "" {
+				validationFailed(w, "name and url are required")
+				return
+			}
+			body.Sys = nil
+			writeJSON(w, http.StatusCreated, s.putWebhook(body))
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	wh := s.webhooks[rest[0]]
+	if wh == nil {
+		notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, wh)
+	case http.MethodPut:
+		if !checkVersion(w, r, wh.Sys, true) {
+			return
+		}
+		body := &gontentful.Webhook{}
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", err.Error())
+			return
+		}
+		sys := *wh.Sys
+		sys.Version++
+		sys.UpdatedAt = now()
+		body.Sys = &sys
+		s.webhooks[sys.ID] = body
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodDelete:
+		delete(s.webhooks, wh.Sys.ID)
+		for i, id := range s.webhookIDs {
+			if id == wh.Sys.ID {
+				s.webhookIDs = append(s.webhookIDs[:i], s.webhookIDs[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// listWebhooks pages the webhook definitions in creation order
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	skip, _ := strconv.Atoi(query.Get("skip"))
+	limit := maxWebhooksLimit
+	if l := query.Get("limit"); l != "" {
+		limit, _ = strconv.Atoi(l)
+	}
+	if limit < 0 || limit > maxWebhooksLimit || skip < 0 {
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("The limit parameter should be between 0 and %d", maxWebhooksLimit))
+		return
+	}
+
+	res := &gontentful.Webhooks{
+		Sys:   &gontentful.Sys{Type: "Array"},
+		Total: len(s.webhookIDs),
+		Skip:  skip,
+		Limit: limit,
+		Items: make([]*gontentful.Webhook, 0),
+	}
+	if skip < len(s.webhookIDs) {
+		end := skip + limit
+		if end > len(s.webhookIDs) {
+			end = len(s.webhookIDs)
+		}
+		for _, id := range s.webhookIDs[skip:end] {
+			res.Items = append(res.Items, s.webhooks[id])
+		}
+	}
+	writeJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,735 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// ImportOptions mirror the skip options of contentful-import
+type ImportOptions struct {
+	SkipContentModel      bool
+	SkipEditorInterfaces  bool
+	SkipContent           bool
+	SkipTags              bool
+	SkipWebhooks          bool
+	SkipLocales           bool
+	SkipContentPublishing bool
+	// NewIDs creates entries and assets with generated ids and rewrites the links to them,
+	// by default they keep their ids and existing ones are overwritten
+	NewIDs bool
+	// ProcessTimeout is the wait for asset files to be processed before publishing, a minute by default
+	ProcessTimeout time.Duration
+}
+
+// ImportItem is the outcome of one imported entity, ID is its id in the target space
+type ImportItem struct {
+	Type      string `json:"type"`
+	SourceID  string `json:"sourceId"`
+	ID        string `json:"id,omitempty"`
+	Action    string `json:"action"`
+	Published bool   `json:"published,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ImportReport lists every imported entity in import order
+type ImportReport struct {
+	Items []*ImportItem `json:"items"`
+}
+
+// IDs maps the source ids of the given type (Entry, Asset...) to their ids in the target space
+func (r *ImportReport) IDs(typ string) map[string]string {
+	res := make(map[string]string)
+	for _, item := range r.Items {
+		if item.Type == typ && item.ID != "" {
+			res[item.SourceID] = item.ID
+		}
+	}
+	return res
+}
+
+// Failed returns the items that could not be imported or published
+func (r *ImportReport) Failed() []*ImportItem {
+	res := make([]*ImportItem, 0)
+	for _, item := range r.Items {
+		if item.Error != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func (r *ImportReport) add(typ string, sourceID string) *ImportItem {
+	item := &ImportItem{Type: typ, SourceID: sourceID}
+	r.Items = append(r.Items, item)
+	return item
+}
+
+func (item *ImportItem) fail(err error) {
+	item.Action = ImportFailed
+	item.Error = err.Error()
+}
+
+// Import writes an export into the space environment of the client: locales, tags, content types
+// and editor interfaces, assets, entries (linked entries first) and webhooks. Entities failing are
+// recorded in the report and do not stop the import, entries and assets published in the export are
+// published once everything is written.
+func (s *SpacesService) Import(export *SpaceExport, opts *ImportOptions) (*ImportReport, error) {
+	return s.ImportContext(context.Background(), export, opts)
+}
+
+func (s *SpacesService) ImportContext(ctx context.Context, export *SpaceExport, opts *ImportOptions) (*ImportReport, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	im := newImporter(s.client, opts)
+	steps := []struct {
+		skip bool
+		run  func(context.Context, *SpaceExport) error
+	}{
+		{opts.SkipLocales, im.locales},
+		{opts.SkipTags, im.tags},
+		{opts.SkipContentModel, im.contentTypes},
+		{opts.SkipContentModel || opts.SkipEditorInterfaces, im.editorInterfaces},
+		{opts.SkipContent, im.assets},
+		{opts.SkipContent, im.entries},
+		{opts.SkipWebhooks, im.webhooks},
+	}
+	for _, step := range steps {
+		if step.skip {
+			continue
+		}
+		if err := step.run(ctx, export); err != nil {
+			return im.report, err
+		}
+	}
+	return im.report, nil
+}
+
+type importer struct {
+	client *Client
+	opts   *ImportOptions
+	report *ImportReport
+	// ids maps the source ids of entries and assets to the target ids
+	ids map[string]map[string]string
+}
+
+func newImporter(client *Client, opts *ImportOptions) *importer {
+	return &importer{
+		client: client,
+		opts:   opts,
+		report: &ImportReport{Items: make([]*ImportItem, 0)},
+		ids: map[string]map[string]string{
+			ENTRY: make(map[string]string),
+			ASSET: make(map[string]string),
+		},
+	}
+}
+
+// locales creates the missing locales, those falling back to a missing locale after it
+func (im *importer) locales(ctx context.Context, export *SpaceExport) error {
+	existing, err := im.client.Locales.GetCMALocalesContext(ctx)
+	if err != nil {
+		return fmt.Errorf("import locales: %w", err)
+	}
+	codes := make(map[string]bool)
+	for _, l := range existing.Items {
+		codes[l.Code] = true
+	}
+
+	pending := make([]*Locale, 0)
+	for _, l := range export.Locales {
+		if codes[l.Code] {
+			item := im.report.add(LOCALE, l.Code)
+			item.ID = l.Code
+			item.Action = ImportSkipped
+			continue
+		}
+		pending = append(pending, l)
+	}
+	for len(pending) > 0 {
+		next := make([]*Locale, 0)
+		for _, l := range pending {
+			if l.FallbackCode != "" && !codes[l.FallbackCode] && containsLocale(pending, l.FallbackCode) {
+				next = append(next, l)
+				continue
+			}
+			item := im.report.add(LOCALE, l.Code)
+			if _, err := im.client.Locales.CreateContext(ctx, l); err != nil {
+				item.fail(err)
+				continue
+			}
+			item.ID = l.Code
+			item.Action = ImportCreated
+			codes[l.Code] = true
+		}
+		if len(next) == len(pending) {
+			// fallback cycle, create the rest without fallbacks
+			for i, l := range next {
+				cp := *l
+				cp.FallbackCode = ""
+				next[i] = &cp
+			}
+		}
+		pending = next
+	}
+	return nil
+}
+
+func containsLocale(locales []*Locale, code string) bool {
+	for _, l := range locales {
+		if l.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (im *importer) tags(ctx context.Context, export *SpaceExport) error {
+	existing := make(map[string]bool)
+	err := paginate(ctx, nil, &PageOptions{Limit: exportPageLimit}, im.client.Tags.GetCMATagsContext, func(p *Tags) int { return p.Total }, func(p *Tags) error {
+		for _, t := range p.Items {
+			existing[t.Sys.ID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("import tags: %w", err)
+	}
+	for _, t := range export.Tags {
+		if t.Sys == nil {
+			continue
+		}
+		item := im.report.add(TAG, t.Sys.ID)
+		item.ID = t.Sys.ID
+		if existing[t.Sys.ID] {
+			item.Action = ImportSkipped
+			continue
+		}
+		visibility := t.Sys.Visibility
+		if visibility == "" {
+			visibility = TagVisibilityPrivate
+		}
+		if _, err := im.client.Tags.CreateContext(ctx, t.Sys.ID, t.Name, visibility); err != nil {
+			item.fail(err)
+			continue
+		}
+		item.Action = ImportCreated
+	}
+	return nil
+}
+
+// contentTypes creates or overwrites the content types and publishes them
+func (im *importer) contentTypes(ctx context.Context, export *SpaceExport) error {
+	for _, raw := range export.ContentTypes {
+		id := rawSysID(raw)
+		item := im.report.add(CONTENT_TYPE, id)
+		if id == "" {
+			item.fail(errors.New("content type without id"))
+			continue
+		}
+		item.ID = id
+		body, err := withoutSys(raw)
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		path := fmt.Sprintf(pathContentType, im.client.Options.SpaceID, im.client.Options.EnvironmentID, id)
+		version, err := im.write(ctx, item, path, body, withContentType(mimeManagement))
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		if _, err := im.client.ContentTypes.PublishContext(ctx, id, strconv.Itoa(version)); err != nil {
+			item.fail(err)
+			continue
+		}
+		item.Published = true
+	}
+	return nil
+}
+
+func (im *importer) editorInterfaces(ctx context.Context, export *SpaceExport) error {
+	for _, ei := range export.EditorInterfaces {
+		if ei.Sys == nil || ei.Sys.ContentType == nil || ei.Sys.ContentType.Sys == nil {
+			continue
+		}
+		id := ei.Sys.ContentType.Sys.ID
+		item := im.report.add(EDITOR_INTERFACE, id)
+		item.ID = id
+		current, err := im.client.ContentTypes.GetEditorInterfaceContext(ctx, id)
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		if _, err := im.client.ContentTypes.UpdateEditorInterfaceContext(ctx, strconv.Itoa(current.Sys.Version), id, ei); err != nil {
+			item.fail(err)
+			continue
+		}
+		item.Action = ImportUpdated
+	}
+	return nil
+}
+
+// assets writes the assets with their files uploaded from the exported urls, processes and publishes them
+func (im *importer) assets(ctx context.Context, export *SpaceExport) error {
+	for _, a := range export.Assets {
+		if a.Sys == nil {
+			continue
+		}
+		item := im.report.add(ASSET, a.Sys.ID)
+		fields, locales := importAssetFields(a.Fields)
+		body, err := json.Marshal(&Asset{Metadata: a.Metadata, Fields: fields})
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+
+		var version int
+		if im.opts.NewIDs {
+			path := fmt.Sprintf(pathAssets, im.client.Options.SpaceID, im.client.Options.EnvironmentID)
+			data, err := im.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+			if err != nil {
+				item.fail(err)
+				continue
+			}
+			created, err := unmarshalAsset(data)
+			if err != nil {
+				item.fail(err)
+				continue
+			}
+			item.ID = created.Sys.ID
+			item.Action = ImportCreated
+			version = created.Sys.Version
+		} else {
+			item.ID = a.Sys.ID
+			path := fmt.Sprintf(pathAsset, im.client.Options.SpaceID, im.client.Options.EnvironmentID, a.Sys.ID)
+			if version, err = im.write(ctx, item, path, body, withContentType(mimeManagement)); err != nil {
+				item.fail(err)
+				continue
+			}
+		}
+		im.ids[ASSET][a.Sys.ID] = item.ID
+
+		if err := im.processAsset(ctx, item, locales, version); err != nil {
+			item.fail(err)
+			continue
+		}
+		if im.opts.SkipContentPublishing || !published(a.Sys) {
+			continue
+		}
+		if err := im.publishAsset(ctx, item, locales, version); err != nil {
+			item.fail(err)
+		}
+	}
+	return nil
+}
+
+func (im *importer) processAsset(ctx context.Context, item *ImportItem, locales []string, version int) error {
+	return im.client.Assets.processLocales(ctx, item.ID, version, locales)
+}
+
+// publishAsset waits for the files of the locales to be processed and publishes the asset
+func (im *importer) publishAsset(ctx context.Context, item *ImportItem, locales []string, version int) error {
+	timeout := im.opts.ProcessTimeout
+	if timeout <= 0 {
+		timeout = defaultProcessTimeout
+	}
+	if len(locales) > 0 {
+		processed, err := im.client.Assets.waitProcessed(ctx, item.ID, locales, timeout)
+		if err != nil {
+			return err
+		}
+		version = processed.Sys.Version
+	}
+	if _, err := im.client.Assets.PublishContext(ctx, item.ID, strconv.Itoa(version)); err != nil {
+		return err
+	}
+	item.Published = true
+	return nil
+}
+
+// importAssetFields replaces the processed files with uploads from their urls,
+// returning the locales to process
+func importAssetFields(fields *AssetFields) (*AssetFields, []string) {
+	locales := make([]string, 0)
+	if fields == nil {
+		return nil, locales
+	}
+	res := &AssetFields{
+		Title:       fields.Title,
+		Description: fields.Description,
+	}
+	if fields.File == nil {
+		return res, locales
+	}
+	res.File = make(map[string]*AssetFile, len(fields.File))
+	for locale, f := range fields.File {
+		if f == nil {
+			continue
+		}
+		file := &AssetFile{
+			FileName:    f.FileName,
+			ContentType: f.ContentType,
+			Upload:      f.Upload,
+			UploadFrom:  f.UploadFrom,
+		}
+		if f.URL != "" {
+			file.Upload = f.URL
+			if strings.HasPrefix(file.Upload, "//") {
+				file.Upload = "https:" + file.Upload
+			}
+			file.UploadFrom = nil
+		}
+		if file.Upload != "" || file.UploadFrom != nil {
+			locales = append(locales, locale)
+		}
+		res.File[locale] = file
+	}
+	return res, locales
+}
+
+// entries writes the entries linked from others first, with new ids the links are rewritten and
+// links to entries created later (cycles) are updated once every entry exists
+func (im *importer) entries(ctx context.Context, export *SpaceExport) error {
+	type written struct {
+		entry   *Entry
+		item    *ImportItem
+		version int
+	}
+	order := SortEntriesByLinks(export.Entries)
+	done := make([]*written, 0, len(order))
+	deferred := make([]*written, 0)
+
+	for _, e := range order {
+		item := im.report.add(ENTRY, e.Sys.ID)
+		contentType := ""
+		if e.Sys.ContentType != nil && e.Sys.ContentType.Sys != nil {
+			contentType = e.Sys.ContentType.Sys.ID
+		}
+		fields, complete := im.rewriteLinks(e.Fields)
+		body, err := json.Marshal(&Entry{Sys: &Sys{}, Metadata: e.Metadata, Fields: fields})
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+
+		w := &written{entry: e, item: item}
+		if im.opts.NewIDs {
+			path := fmt.Sprintf(pathEntries, im.client.Options.SpaceID, im.client.Options.EnvironmentID)
+			data, err := im.client.post(ctx, path, bytes.NewBuffer(body), withContentfulContentType(contentType))
+			if err != nil {
+				item.fail(err)
+				continue
+			}
+			created, err := unmarshalEntry(data)
+			if err != nil {
+				item.fail(err)
+				continue
+			}
+			item.ID = created.Sys.ID
+			item.Action = ImportCreated
+			w.version = created.Sys.Version
+		} else {
+			item.ID = e.Sys.ID
+			path := fmt.Sprintf(pathEntry, im.client.Options.SpaceID, im.client.Options.EnvironmentID, e.Sys.ID)
+			if w.version, err = im.write(ctx, item, path, body, withContentfulContentType(contentType)); err != nil {
+				item.fail(err)
+				continue
+			}
+		}
+		im.ids[ENTRY][e.Sys.ID] = item.ID
+		done = append(done, w)
+		if !complete {
+			deferred = append(deferred, w)
+		}
+	}
+
+	for _, w := range deferred {
+		fields, _ := im.rewriteLinks(w.entry.Fields)
+		body, err := json.Marshal(&Entry{Sys: &Sys{}, Metadata: w.entry.Metadata, Fields: fields})
+		if err != nil {
+			w.item.fail(err)
+			continue
+		}
+		data, err := im.client.Entries.UpdateContext(ctx, strconv.Itoa(w.version), w.item.ID, body)
+		if err != nil {
+			w.item.fail(err)
+			continue
+		}
+		updated, err := unmarshalEntry(data)
+		if err != nil {
+			w.item.fail(err)
+			continue
+		}
+		w.version = updated.Sys.Version
+	}
+
+	if im.opts.SkipContentPublishing {
+		return nil
+	}
+	items := make([]BulkItem, 0)
+	publish := make(map[string]*ImportItem)
+	for _, w := range done {
+		if w.item.Error != "" || !published(w.entry.Sys) {
+			continue
+		}
+		items = append(items, BulkItem{LinkType: ENTRY, ID: w.item.ID, Version: w.version})
+		publish[w.item.ID] = w.item
+	}
+	return im.publishEntries(ctx, items, publish)
+}
+
+// publishEntries publishes the entries in bulk and records the outcome on their items
+func (im *importer) publishEntries(ctx context.Context, items []BulkItem, publish map[string]*ImportItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	report, err := im.client.BulkActions.RunContext(ctx, BulkActionPublish, items, 0)
+	if report != nil {
+		for _, r := range report.Items {
+			item := publish[r.ID]
+			if item == nil {
+				// not an entry of this import
+				continue
+			}
+			if r.Status == BulkActionStatusSucceeded {
+				item.Published = true
+			} else if r.Error != nil {
+				item.Error = "publish: " + r.Error.Error()
+			} else {
+				item.Error = "publish: " + r.Status
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("publish entries: %w", err)
+	}
+	return nil
+}
+
+// rewriteLinks maps the entry and asset links of the fields to the target ids, complete is false
+// when an entry it links to has not been written yet
+func (im *importer) rewriteLinks(fields Fields) (Fields, bool) {
+	if !im.opts.NewIDs {
+		return fields, true
+	}
+	complete := true
+	var rewrite func(v interface{}) interface{}
+	rewrite = func(v interface{}) interface{} {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if linkType, id, ok := parseLink(t); ok {
+				ids := im.ids[linkType]
+				if ids == nil {
+					return t
+				}
+				target, ok := ids[id]
+				if !ok {
+					complete = false
+					return t
+				}
+				sys := make(map[string]interface{})
+				for k, sv := range t["sys"].(map[string]interface{}) {
+					sys[k] = sv
+				}
+				sys["id"] = target
+				return map[string]interface{}{"sys": sys}
+			}
+			res := make(map[string]interface{}, len(t))
+			for k, fv := range t {
+				res[k] = rewrite(fv)
+			}
+			return res
+		case []interface{}:
+			res := make([]interface{}, len(t))
+			for i, fv := range t {
+				res[i] = rewrite(fv)
+			}
+			return res
+		}
+		return v
+	}
+	res := make(Fields, len(fields))
+	for k, v := range fields {
+		res[k] = rewrite(v)
+	}
+	return res, complete
+}
+
+func (im *importer) webhooks(ctx context.Context, export *SpaceExport) error {
+	names := make(map[string]string)
+	err := paginate(ctx, nil, &PageOptions{Limit: exportPageLimit}, im.client.Webhooks.getWebhooksContext, func(p *Webhooks) int { return p.Total }, func(p *Webhooks) error {
+		for _, w := range p.Items {
+			names[w.Name] = w.Sys.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("import webhooks: %w", err)
+	}
+	for _, w := range export.Webhooks {
+		sourceID := ""
+		if w.Sys != nil {
+			sourceID = w.Sys.ID
+		}
+		item := im.report.add(WEBHOOK, sourceID)
+		if id, ok := names[w.Name]; ok {
+			item.ID = id
+			item.Action = ImportSkipped
+			continue
+		}
+		created, err := im.client.Webhooks.CreateContext(ctx, w)
+		if err != nil {
+			item.fail(err)
+			continue
+		}
+		item.ID = created.Sys.ID
+		item.Action = ImportCreated
+	}
+	return nil
+}
+
+// write creates the entity at path or overwrites the current version, returning the new version
+func (im *importer) write(ctx context.Context, item *ImportItem, path string, body []byte, opts ...reqOption) (int, error) {
+	current, err := im.client.getCMA(ctx, path, nil)
+	var notFound NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		item.Action = ImportCreated
+	case err != nil:
+		return 0, err
+	default:
+		version, err := sysVersion(current)
+		if err != nil {
+			return 0, err
+		}
+		item.Action = ImportUpdated
+		opts = append(opts, withVersion(strconv.Itoa(version)))
+	}
+	data, err := im.client.put(ctx, path, bytes.NewBuffer(body), opts...)
+	if err != nil {
+		return 0, err
+	}
+	return sysVersion(data)
+}
+
+// SortEntriesByLinks orders the entries so that linked entries come before the entries linking
+// to them, keeping the given order otherwise. Entries on link cycles follow in the given order.
+func SortEntriesByLinks(entries []*Entry) []*Entry {
+	index := make(map[string]int, len(entries))
+	for i, e := range entries {
+		if e != nil && e.Sys != nil {
+			index[e.Sys.ID] = i
+		}
+	}
+
+	pending := make([]int, len(entries))
+	dependents := make([][]int, len(entries))
+	for i, e := range entries {
+		if e == nil || e.Sys == nil {
+			continue
+		}
+		seen := make(map[int]bool)
+		for _, l := range fieldLinks(e.Fields) {
+			j, ok := index[l.ID]
+			if l.LinkType != ENTRY || !ok || j == i || seen[j] {
+				continue
+			}
+			seen[j] = true
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	res := make([]*Entry, 0, len(entries))
+	added := make([]bool, len(entries))
+	queue := make([]int, 0)
+	for i, e := range entries {
+		if e != nil && e.Sys != nil && pending[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		res = append(res, entries[i])
+		added[i] = true
+		for _, d := range dependents[i] {
+			pending[d]--
+			if pending[d] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+	for i, e := range entries {
+		if e != nil && e.Sys != nil && !added[i] {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// fieldLinks returns the links in the fields, including those of rich text documents
+func fieldLinks(fields Fields) []Link {
+	links := make([]Link, 0)
+	var collect func(v interface{})
+	collect = func(v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if linkType, id, ok := parseLink(t); ok {
+				links = append(links, Link{LinkType: linkType, ID: id})
+				return
+			}
+			for _, fv := range t {
+				collect(fv)
+			}
+		case []interface{}:
+			for _, fv := range t {
+				collect(fv)
+			}
+		}
+	}
+	for _, v := range fields {
+		collect(v)
+	}
+	return links
+}
+
+func published(sys *Sys) bool {
+	return sys != nil && (sys.PublishedVersion > 0 || sys.PublishedCounter > 0)
+}
+
+func withoutSys(raw json.RawMessage) ([]byte, error) {
+	m := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	delete(m, "sys")
+	return json.Marshal(m)
+}
+
+func sysVersion(data []byte) (int, error) {
+	v := struct {
+		Sys *Sys `json:"sys"`
+	}{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return 0, err
+	}
+	if v.Sys == nil {
+		return 0, errors.New("response without sys")
+	}
+	return v.Sys.Version, nil
+}
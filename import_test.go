@@ -0,0 +1,91 @@
+package gontentful_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+	"github.com/moonwalker/gontentful/gontentfultest"
+)
+
+func TestExportImport(t *testing.T) {
+	source, sourceClient := newFake(t)
+	source.AddContentType(&gontentful.ContentType{
+		Sys:          &gontentful.Sys{ID: "page"},
+		Name:         "Page",
+		DisplayField: "title",
+		Fields: []*gontentful.ContentTypeField{
+			{ID: "title", Name: "Title", Type: "Symbol"},
+			{ID: "parent", Name: "Parent", Type: "Link", LinkType: gontentful.ENTRY},
+		},
+	})
+	source.AddEntry(entry("child", "page", gontentful.Fields{"title": localized("Child"), "parent": localized(link(gontentful.ENTRY, "home"))}))
+	source.AddEntry(entry("home", "page", gontentful.Fields{"title": localized("Home")}))
+
+	skip := &gontentful.ExportOptions{SkipEditorInterfaces: true, SkipTags: true, SkipWebhooks: true}
+	export, err := sourceClient.Spaces.Export(skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.ContentTypes) != 1 || len(export.Entries) != 2 {
+		t.Fatalf("got %d content types and %d entries", len(export.ContentTypes), len(export.Entries))
+	}
+
+	target, targetClient := newFake(t)
+	report, err := targetClient.Spaces.Import(export, &gontentful.ImportOptions{SkipEditorInterfaces: true, SkipTags: true, SkipWebhooks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range report.Failed() {
+		t.Errorf("%s %s failed: %s", item.Type, item.SourceID, item.Error)
+	}
+	for _, id := range []string{"home", "child"} {
+		e := target.Entry(id)
+		if e == nil || e.Sys.PublishedVersion == 0 {
+			t.Errorf("entry %s is not published in the target", id)
+		}
+	}
+	parent := target.Entry("child").Fields["parent"].(map[string]interface{})[gontentful.DefaultLocale]
+	if sys := parent.(map[string]interface{})["sys"].(map[string]interface{}); sys["id"] != "home" {
+		t.Errorf("got parent %v", parent)
+	}
+}
+
+func addWebhooks(srv *gontentfultest.Server, n int) {
+	for i := 0; i < n; i++ {
+		srv.AddWebhook(&gontentful.Webhook{Name: fmt.Sprintf("hook%03d", i), URL: "https://example.com/hooks", Topics: []string{"Entry.publish"}})
+	}
+}
+
+func TestExportImportWebhooks(t *testing.T) {
+	source, sourceClient := newFake(t)
+	addWebhooks(source, 150)
+
+	export, err := sourceClient.Spaces.Export(&gontentful.ExportOptions{SkipContentModel: true, SkipContent: true, SkipTags: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.Webhooks) != 150 {
+		t.Fatalf("got %d webhooks, want every page", len(export.Webhooks))
+	}
+	if n := countRequests(source, "GET /cma/spaces/space/webhook_definitions"); n != 2 {
+		t.Errorf("got %d pages, want 2", n)
+	}
+
+	// the target has the first 120 webhooks already, some of them on its second page
+	target, targetClient := newFake(t)
+	addWebhooks(target, 120)
+	report, err := targetClient.Spaces.Import(export, &gontentful.ImportOptions{SkipContentModel: true, SkipContent: true, SkipLocales: true, SkipTags: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := make(map[string]int)
+	for _, item := range report.Items {
+		if item.Type == gontentful.WEBHOOK {
+			actions[item.Action]++
+		}
+	}
+	if actions[gontentful.ImportSkipped] != 120 || actions[gontentful.ImportCreated] != 30 {
+		t.Errorf("got %v, want 120 skipped and 30 created", actions)
+	}
+}
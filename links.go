@@ -0,0 +1,156 @@
+package gontentful
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+const (
+	defaultInclude = 1
+)
+
+// Link identifies a linked entry or asset
+type Link struct {
+	LinkType string
+	ID       string
+}
+
+// ResolveLinks replaces the link objects in the fields of the items (including
+// arrays of links) with copies of the linked entries and assets found in the
+// items and includes, resolving up to depth levels like the include parameter
+// of the delivery api. Links back to an entry on the current path (cycles) are
+// left in place, as are links missing from the response, which are returned.
+func (e *Entries) ResolveLinks(depth int) []Link {
+	r := &linkResolver{
+		links:      make(linkIndex),
+		depth:      depth,
+		unresolved: make([]Link, 0),
+		seen:       make(map[Link]bool),
+	}
+	r.links.add(e.Includes)
+	for _, item := range e.Items {
+		r.links.addEntry(item)
+	}
+
+	for i, item := range e.Items {
+		e.Items[i] = r.entry(item, map[string]bool{}, 0)
+	}
+	return r.unresolved
+}
+
+// GetEntriesResolved gets the entries with their links resolved as deep as the include query parameter
+func (s *EntriesService) GetEntriesResolved(query url.Values) (*Entries, error) {
+	return s.GetEntriesResolvedContext(context.Background(), query)
+}
+
+func (s *EntriesService) GetEntriesResolvedContext(ctx context.Context, query url.Values) (*Entries, error) {
+	res, err := s.GetEntriesContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	depth := defaultInclude
+	if i, err := strconv.Atoi(query.Get("include")); err == nil {
+		depth = i
+	}
+	res.ResolveLinks(depth)
+	return res, nil
+}
+
+type linkResolver struct {
+	links      linkIndex
+	depth      int
+	unresolved []Link
+	seen       map[Link]bool
+}
+
+func (r *linkResolver) entry(e *Entry, path map[string]bool, depth int) *Entry {
+	if e == nil || e.Sys == nil {
+		return e
+	}
+	path[e.Sys.ID] = true
+	defer delete(path, e.Sys.ID)
+
+	res := &Entry{
+		Sys:    e.Sys,
+		Locale: e.Locale,
+		Fields: make(Fields, len(e.Fields)),
+	}
+	for k, v := range e.Fields {
+		res.Fields[k] = r.value(v, path, depth)
+	}
+	return res
+}
+
+func (r *linkResolver) value(v interface{}, path map[string]bool, depth int) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if linkType, id, ok := parseLink(t); ok {
+			target := r.links.get(linkType, id)
+			if target == nil {
+				r.missing(Link{LinkType: linkType, ID: id})
+				return t
+			}
+			if path[id] || depth >= r.depth {
+				return t
+			}
+			return r.entry(target, path, depth+1)
+		}
+		res := make(map[string]interface{}, len(t))
+		for k, fv := range t {
+			res[k] = r.value(fv, path, depth)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i, fv := range t {
+			res[i] = r.value(fv, path, depth)
+		}
+		return res
+	}
+	return v
+}
+
+func (r *linkResolver) missing(l Link) {
+	if !r.seen[l] {
+		r.seen[l] = true
+		r.unresolved = append(r.unresolved, l)
+	}
+}
+
+// linkIndex finds entries and assets by link type and id
+type linkIndex map[string]*Entry
+
+func (idx linkIndex) add(includes *Include) {
+	if includes == nil {
+		return
+	}
+	for _, e := range includes.Entry {
+		idx.addEntry(e)
+	}
+	for _, a := range includes.Asset {
+		idx.addEntry(a)
+	}
+}
+
+func (idx linkIndex) addEntry(e *Entry) {
+	if e == nil || e.Sys == nil {
+		return
+	}
+	idx[e.Sys.Type+":"+e.Sys.ID] = e
+}
+
+func (idx linkIndex) get(linkType string, id string) *Entry {
+	return idx[linkType+":"+id]
+}
+
+// parseLink returns the link type and id of a {"sys":{"type":"Link"}} object
+func parseLink(m map[string]interface{}) (string, string, bool) {
+	sys, ok := m["sys"].(map[string]interface{})
+	if !ok || sys["type"] != "Link" {
+		return "", "", false
+	}
+	linkType, _ := sys["linkType"].(string)
+	id, _ := sys["id"].(string)
+	return linkType, id, id != ""
+}
@@ -0,0 +1,80 @@
+package gontentful_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+// linked returns the resolved entry or the link left in a field
+func linked(t *testing.T, e *gontentful.Entry, field string) (*gontentful.Entry, map[string]interface{}) {
+	t.Helper()
+	switch v := e.Fields[field].(type) {
+	case *gontentful.Entry:
+		return v, nil
+	case map[string]interface{}:
+		return nil, v
+	}
+	t.Fatalf("field %s of %s is %T", field, e.Sys.ID, e.Fields[field])
+	return nil, nil
+}
+
+func TestResolveLinks(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddAsset(&gontentful.Entry{Sys: &gontentful.Sys{ID: "logo"}, Fields: gontentful.Fields{"title": localized("Logo")}})
+	srv.AddEntry(entry("a", "page", gontentful.Fields{
+		"next":    localized(link(gontentful.ENTRY, "b")),
+		"missing": localized(link(gontentful.ENTRY, "gone")),
+		"images":  localized([]interface{}{link(gontentful.ASSET, "logo")}),
+	}))
+	srv.AddEntry(entry("b", "page", gontentful.Fields{"next": localized(link(gontentful.ENTRY, "a"))}))
+
+	res, err := client.Entries.GetEntries(url.Values{"sys.id": []string{"a"}, "include": []string{"2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unresolved := res.ResolveLinks(2)
+	if len(unresolved) != 1 || unresolved[0] != (gontentful.Link{LinkType: gontentful.ENTRY, ID: "gone"}) {
+		t.Errorf("got unresolved %v, want the missing entry", unresolved)
+	}
+
+	a := res.Items[0]
+	b, _ := linked(t, a, "next")
+	if b == nil || b.Sys.ID != "b" {
+		t.Fatalf("got next %v, want b resolved", a.Fields["next"])
+	}
+	// b links back to a, the cycle is left as a link
+	if _, back := linked(t, b, "next"); back == nil {
+		t.Errorf("got %v, want the link back to a", b.Fields["next"])
+	}
+	if _, missing := linked(t, a, "missing"); missing == nil {
+		t.Errorf("got %v, want the missing link kept", a.Fields["missing"])
+	}
+	images, ok := a.Fields["images"].([]interface{})
+	if !ok || len(images) != 1 {
+		t.Fatalf("got images %v", a.Fields["images"])
+	}
+	if logo, ok := images[0].(*gontentful.Entry); !ok || logo.Sys.ID != "logo" {
+		t.Errorf("got image %v, want the asset resolved", images[0])
+	}
+}
+
+func TestResolveLinksDepth(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"next": localized(link(gontentful.ENTRY, "b"))}))
+	srv.AddEntry(entry("b", "page", gontentful.Fields{"next": localized(link(gontentful.ENTRY, "c"))}))
+	srv.AddEntry(entry("c", "page", gontentful.Fields{}))
+
+	res, err := client.Entries.GetEntriesResolved(url.Values{"sys.id": []string{"a"}, "include": []string{"1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := linked(t, res.Items[0], "next")
+	if b == nil {
+		t.Fatal("b is not resolved at depth 1")
+	}
+	if _, c := linked(t, b, "next"); c == nil {
+		t.Errorf("got %v, want c left as a link past depth 1", b.Fields["next"])
+	}
+}
@@ -1,6 +1,8 @@
 package gontentful
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -10,15 +12,48 @@ type LocalesService service
 
 const (
 	DefaultLocale = "en"
+	LOCALE        = "Locale"
 )
 
+// localeBody is the writable part of a locale, an empty fallback code is sent as null
+type localeBody struct {
+	Code                 string  `json:"code"`
+	Name                 string  `json:"name"`
+	FallbackCode         *string `json:"fallbackCode"`
+	Optional             bool    `json:"optional"`
+	ContentDeliveryAPI   *bool   `json:"contentDeliveryApi,omitempty"`
+	ContentManagementAPI *bool   `json:"contentManagementApi,omitempty"`
+}
+
+func newLocaleBody(l *Locale) ([]byte, error) {
+	body := &localeBody{
+		Code:                 l.Code,
+		Name:                 l.Name,
+		Optional:             l.Optional,
+		ContentDeliveryAPI:   l.ContentDeliveryAPI,
+		ContentManagementAPI: l.ContentManagementAPI,
+	}
+	if l.FallbackCode != "" {
+		body.FallbackCode = &l.FallbackCode
+	}
+	return json.Marshal(body)
+}
+
 func (s *LocalesService) Get(query url.Values) ([]byte, error) {
+	return s.GetContext(context.Background(), query)
+}
+
+func (s *LocalesService) GetContext(ctx context.Context, query url.Values) ([]byte, error) {
 	path := fmt.Sprintf(pathLocales, s.client.Options.SpaceID)
-	return s.client.get(path, query)
+	return s.client.get(ctx, path, query)
 }
 
 func (s *LocalesService) GetLocales() (*Locales, error) {
-	data, err := s.Get(nil)
+	return s.GetLocalesContext(context.Background())
+}
+
+func (s *LocalesService) GetLocalesContext(ctx context.Context) (*Locales, error) {
+	data, err := s.GetContext(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -29,3 +64,90 @@ func (s *LocalesService) GetLocales() (*Locales, error) {
 	}
 	return res, nil
 }
+
+func (s *LocalesService) GetCMALocales() (*Locales, error) {
+	return s.GetCMALocalesContext(context.Background())
+}
+
+func (s *LocalesService) GetCMALocalesContext(ctx context.Context) (*Locales, error) {
+	path := fmt.Sprintf(pathEnvLocales, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &Locales{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetSingleCMA fetches a locale by its id (sys.id), not by its code
+func (s *LocalesService) GetSingleCMA(localeId string) (*Locale, error) {
+	return s.GetSingleCMAContext(context.Background(), localeId)
+}
+
+func (s *LocalesService) GetSingleCMAContext(ctx context.Context, localeId string) (*Locale, error) {
+	path := fmt.Sprintf(pathLocale, s.client.Options.SpaceID, s.client.Options.EnvironmentID, localeId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalLocale(data)
+}
+
+// Create adds a locale to the environment, it is enabled on the delivery and
+// management apis unless ContentDeliveryAPI or ContentManagementAPI are set to false.
+func (s *LocalesService) Create(locale *Locale) (*Locale, error) {
+	return s.CreateContext(context.Background(), locale)
+}
+
+func (s *LocalesService) CreateContext(ctx context.Context, locale *Locale) (*Locale, error) {
+	body, err := newLocaleBody(locale)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathEnvLocales, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalLocale(data)
+}
+
+func (s *LocalesService) Update(version string, localeId string, locale *Locale) (*Locale, error) {
+	return s.UpdateContext(context.Background(), version, localeId, locale)
+}
+
+func (s *LocalesService) UpdateContext(ctx context.Context, version string, localeId string, locale *Locale) (*Locale, error) {
+	body, err := newLocaleBody(locale)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathLocale, s.client.Options.SpaceID, s.client.Options.EnvironmentID, localeId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalLocale(data)
+}
+
+func (s *LocalesService) Delete(localeId string) error {
+	return s.DeleteContext(context.Background(), localeId)
+}
+
+func (s *LocalesService) DeleteContext(ctx context.Context, localeId string) error {
+	path := fmt.Sprintf(pathLocale, s.client.Options.SpaceID, s.client.Options.EnvironmentID, localeId)
+	_, err := s.client.delete(ctx, path)
+	return err
+}
+
+func unmarshalLocale(data []byte) (*Locale, error) {
+	res := &Locale{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,27 @@
+package gontentful_test
+
+import (
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestCreateLocaleDefaultsAPIFlags(t *testing.T) {
+	_, client := newFake(t)
+
+	locale, err := client.Locales.Create(&gontentful.Locale{Code: "de", Name: "German", FallbackCode: gontentful.DefaultLocale})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locale.ContentDeliveryAPI == nil || !*locale.ContentDeliveryAPI || locale.ContentManagementAPI == nil || !*locale.ContentManagementAPI {
+		t.Errorf("got delivery %v management %v, want both enabled", locale.ContentDeliveryAPI, locale.ContentManagementAPI)
+	}
+	hidden := false
+	locale, err = client.Locales.Create(&gontentful.Locale{Code: "fr", Name: "French", ContentDeliveryAPI: &hidden})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *locale.ContentDeliveryAPI || !*locale.ContentManagementAPI {
+		t.Errorf("got delivery %v management %v, want only management enabled", *locale.ContentDeliveryAPI, *locale.ContentManagementAPI)
+	}
+}
@@ -0,0 +1,224 @@
+package gontentful
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const defaultModifyAttempts = 5
+
+// ModifyOptions control the read-modify-write helpers, nil uses the defaults
+type ModifyOptions struct {
+	// MaxAttempts is the number of read-modify-write rounds before a version mismatch is returned, 5 by default
+	MaxAttempts int
+	// Publish publishes the updated version, a version mismatch on publishing is returned
+	// as the update may no longer be the latest version
+	Publish bool
+	// PublishLatest publishes the latest version instead when publishing the update conflicts,
+	// it may include changes saved by others after the update
+	PublishLatest bool
+}
+
+func (o *ModifyOptions) maxAttempts() int {
+	if o == nil || o.MaxAttempts <= 0 {
+		return defaultModifyAttempts
+	}
+	return o.MaxAttempts
+}
+
+func (o *ModifyOptions) publish() bool {
+	return o != nil && o.Publish
+}
+
+func (o *ModifyOptions) publishLatest() bool {
+	return o != nil && o.PublishLatest
+}
+
+// GetSingleCMA returns the current (draft) version of the entry from the management api
+func (s *EntriesService) GetSingleCMA(entryId string) (*Entry, error) {
+	return s.GetSingleCMAContext(context.Background(), entryId)
+}
+
+func (s *EntriesService) GetSingleCMAContext(ctx context.Context, entryId string) (*Entry, error) {
+	path := fmt.Sprintf(pathEntry, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalEntry(data)
+}
+
+// Modify fetches the entry, applies fn to it and saves its fields and metadata at the fetched version.
+// On a version mismatch the entry is fetched again and fn applied to the fresh copy, so fn may run
+// more than once and should only depend on the entry passed in. A version mismatch on publishing
+// is returned, unless PublishLatest publishes the latest version without running fn again.
+func (s *EntriesService) Modify(entryId string, fn func(e *Entry) error, opts *ModifyOptions) (*Entry, error) {
+	return s.ModifyContext(context.Background(), entryId, fn, opts)
+}
+
+func (s *EntriesService) ModifyContext(ctx context.Context, entryId string, fn func(e *Entry) error, opts *ModifyOptions) (*Entry, error) {
+	var res *Entry
+	err := s.client.modify(ctx, opts, func(ctx context.Context) error {
+		e, err := s.GetSingleCMAContext(ctx, entryId)
+		if err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+		body, err := json.Marshal(&Entry{Sys: &Sys{}, Metadata: e.Metadata, Fields: e.Fields})
+		if err != nil {
+			return err
+		}
+		data, err := s.UpdateContext(ctx, strconv.Itoa(e.Sys.Version), entryId, body)
+		if err != nil {
+			return err
+		}
+		res, err = unmarshalEntry(data)
+		return err
+	})
+	if err == nil && opts.publish() {
+		res, err = s.publishUpdate(ctx, res, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("modify entry %s: %w", entryId, err)
+	}
+	return res, nil
+}
+
+// publishUpdate publishes the updated entry, with PublishLatest a version mismatch publishes
+// the latest version without applying fn again, the update is already saved
+func (s *EntriesService) publishUpdate(ctx context.Context, e *Entry, opts *ModifyOptions) (*Entry, error) {
+	if !opts.publishLatest() {
+		data, err := s.PublishContext(ctx, e.Sys.ID, strconv.Itoa(e.Sys.Version))
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalEntry(data)
+	}
+	err := s.client.modify(ctx, opts, func(ctx context.Context) error {
+		data, err := s.PublishContext(ctx, e.Sys.ID, strconv.Itoa(e.Sys.Version))
+		if err != nil {
+			var conflict VersionMismatchError
+			if errors.As(err, &conflict) {
+				if latest, err := s.GetSingleCMAContext(ctx, e.Sys.ID); err == nil {
+					e = latest
+				}
+			}
+			return err
+		}
+		e, err = unmarshalEntry(data)
+		return err
+	})
+	return e, err
+}
+
+// Modify fetches the content type, applies fn to it and saves it at the fetched version,
+// retrying on version mismatch like EntriesService.Modify
+func (s *ContentTypesService) Modify(contentTypeId string, fn func(ct *ContentType) error, opts *ModifyOptions) (*ContentType, error) {
+	return s.ModifyContext(context.Background(), contentTypeId, fn, opts)
+}
+
+func (s *ContentTypesService) ModifyContext(ctx context.Context, contentTypeId string, fn func(ct *ContentType) error, opts *ModifyOptions) (*ContentType, error) {
+	var res *ContentType
+	err := s.client.modify(ctx, opts, func(ctx context.Context) error {
+		ct, err := s.GetSingleCMAContext(ctx, contentTypeId)
+		if err != nil {
+			return err
+		}
+		if err := fn(ct); err != nil {
+			return err
+		}
+		body, err := json.Marshal(&ContentType{
+			Sys:          &Sys{},
+			Name:         ct.Name,
+			Description:  ct.Description,
+			Fields:       ct.Fields,
+			DisplayField: ct.DisplayField,
+		})
+		if err != nil {
+			return err
+		}
+		data, err := s.UpdateContext(ctx, contentTypeId, body, strconv.Itoa(ct.Sys.Version))
+		if err != nil {
+			return err
+		}
+		res, err = unmarshalContentType(data)
+		return err
+	})
+	if err == nil && opts.publish() {
+		res, err = s.publishUpdate(ctx, res, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("modify content type %s: %w", contentTypeId, err)
+	}
+	return res, nil
+}
+
+// publishUpdate publishes the updated content type, with PublishLatest a version mismatch publishes the latest version
+func (s *ContentTypesService) publishUpdate(ctx context.Context, ct *ContentType, opts *ModifyOptions) (*ContentType, error) {
+	if !opts.publishLatest() {
+		data, err := s.PublishContext(ctx, ct.Sys.ID, strconv.Itoa(ct.Sys.Version))
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalContentType(data)
+	}
+	err := s.client.modify(ctx, opts, func(ctx context.Context) error {
+		data, err := s.PublishContext(ctx, ct.Sys.ID, strconv.Itoa(ct.Sys.Version))
+		if err != nil {
+			var conflict VersionMismatchError
+			if errors.As(err, &conflict) {
+				if latest, err := s.GetSingleCMAContext(ctx, ct.Sys.ID); err == nil {
+					ct = latest
+				}
+			}
+			return err
+		}
+		ct, err = unmarshalContentType(data)
+		return err
+	})
+	return ct, err
+}
+
+// modify runs a read-modify-write round until it does not fail with a version mismatch,
+// backing off between rounds like the retry policy of the client
+func (c *Client) modify(ctx context.Context, opts *ModifyOptions, round func(ctx context.Context) error) error {
+	policy := c.retryPolicy()
+	attempts := opts.maxAttempts()
+
+	for attempt := 1; ; attempt++ {
+		err := round(ctx)
+		var conflict VersionMismatchError
+		if err == nil || !errors.As(err, &conflict) {
+			return err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("gave up after %d attempts: %w", attempts, err)
+		}
+		if err := sleep(ctx, policy.delay(attempt, nil)); err != nil {
+			return err
+		}
+	}
+}
+
+func unmarshalEntry(data []byte) (*Entry, error) {
+	res := &Entry{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func unmarshalContentType(data []byte) (*ContentType, error) {
+	res := &ContentType{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
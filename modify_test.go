@@ -0,0 +1,97 @@
+package gontentful_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestModifyRetriesUpdate(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"count": localized(float64(1))}))
+	srv.InjectVersionMismatch("PUT", "/entries/a", 1)
+
+	runs := 0
+	_, err := client.Entries.Modify("a", func(e *gontentful.Entry) error {
+		runs++
+		e.Fields["count"] = localized(e.Fields["count"].(map[string]interface{})[gontentful.DefaultLocale].(float64) + 1)
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs != 2 {
+		t.Errorf("got %d runs, want 2", runs)
+	}
+}
+
+func TestModifyPublishConflict(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"count": localized(float64(1))}))
+	srv.InjectVersionMismatch("PUT", "/entries/a/published", 1)
+
+	_, err := client.Entries.Modify("a", func(e *gontentful.Entry) error {
+		e.Fields["count"] = localized(float64(2))
+		return nil
+	}, &gontentful.ModifyOptions{Publish: true})
+	var conflict gontentful.VersionMismatchError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("got %v, want a version mismatch", err)
+	}
+	if n := countRequests(srv, "PUT /cma/spaces/space/environments/master/entries/a/published"); n != 1 {
+		t.Errorf("got %d publishes, want 1", n)
+	}
+	// the update is saved as a draft
+	if e := srv.Entry("a"); e.Sys.Version != e.Sys.PublishedVersion+2 {
+		t.Errorf("got sys %+v, want the update unpublished", e.Sys)
+	}
+}
+
+func TestModifyPublishLatestConflictRunsOnce(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"count": localized(float64(1))}))
+	srv.InjectVersionMismatch("PUT", "/entries/a/published", 1)
+
+	runs := 0
+	res, err := client.Entries.Modify("a", func(e *gontentful.Entry) error {
+		runs++
+		e.Fields["count"] = localized(e.Fields["count"].(map[string]interface{})[gontentful.DefaultLocale].(float64) + 1)
+		return nil
+	}, &gontentful.ModifyOptions{Publish: true, PublishLatest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs != 1 {
+		t.Errorf("got %d runs, want 1", runs)
+	}
+	if n := countRequests(srv, "PUT "); n != 3 {
+		t.Errorf("got %d writes, want an update and two publishes", n)
+	}
+	e := srv.Entry("a")
+	if got := e.Fields["count"].(map[string]interface{})[gontentful.DefaultLocale]; got != float64(2) {
+		t.Errorf("got count %v, want 2", got)
+	}
+	if e.Sys.PublishedVersion != res.Sys.PublishedVersion || e.Sys.Version != e.Sys.PublishedVersion+1 {
+		t.Errorf("got sys %+v, want the update published", e.Sys)
+	}
+}
+
+func TestModifyContentTypePublishLatestConflictRunsOnce(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddContentType(&gontentful.ContentType{Sys: &gontentful.Sys{ID: "page"}, Name: "Page"})
+	srv.InjectVersionMismatch("PUT", "/content_types/page/published", 1)
+
+	runs := 0
+	res, err := client.ContentTypes.Modify("page", func(ct *gontentful.ContentType) error {
+		runs++
+		ct.Description += "!"
+		return nil
+	}, &gontentful.ModifyOptions{Publish: true, PublishLatest: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs != 1 || res.Description != "!" {
+		t.Errorf("got %d runs and description %q, want 1 run and \"!\"", runs, res.Description)
+	}
+}
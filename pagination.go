@@ -0,0 +1,257 @@
+package gontentful
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	maxPageLimit       = 1000
+	defaultConcurrency = 4
+)
+
+// StopPaging can be returned from a page or item callback to end the iteration early without an error
+var StopPaging = errors.New("stop paging")
+
+// PageOptions configures the collection iterators, zero values use the defaults
+type PageOptions struct {
+	// Limit is the page size, at most 1000 (the delivery api maximum)
+	Limit int
+	// Concurrency is the number of pages fetched in parallel after the first one
+	Concurrency int
+}
+
+func (o *PageOptions) limit() int {
+	if o == nil || o.Limit <= 0 || o.Limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return o.Limit
+}
+
+func (o *PageOptions) concurrency() int {
+	if o == nil || o.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+	return o.Concurrency
+}
+
+type pageResult[P any] struct {
+	page P
+	err  error
+}
+
+// paginate fetches the pages of a skip/limit collection, the first one alone to learn the total,
+// the rest with bounded concurrency, and hands them to fn in order
+func paginate[P any](ctx context.Context, query url.Values, opts *PageOptions, fetch func(context.Context, url.Values) (P, error), total func(P) int, fn func(P) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	limit := opts.limit()
+	skip := 0
+	if s := query.Get("skip"); s != "" {
+		var err error
+		if skip, err = strconv.Atoi(s); err != nil {
+			return fmt.Errorf("invalid skip: %s", s)
+		}
+	}
+
+	pageQuery := func(skip int) url.Values {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("skip", strconv.Itoa(skip))
+		q.Set("limit", strconv.Itoa(limit))
+		return q
+	}
+
+	first, err := fetch(ctx, pageQuery(skip))
+	if err != nil {
+		return err
+	}
+	if err := fn(first); err != nil {
+		return stopped(err)
+	}
+
+	count := total(first)
+	next := skip + limit
+	inflight := make([]chan pageResult[P], 0, opts.concurrency())
+	launch := func() {
+		if next >= count {
+			return
+		}
+		q := pageQuery(next)
+		ch := make(chan pageResult[P], 1)
+		go func() {
+			p, err := fetch(ctx, q)
+			ch <- pageResult[P]{page: p, err: err}
+		}()
+		inflight = append(inflight, ch)
+		next += limit
+	}
+
+	for i := 0; i < opts.concurrency(); i++ {
+		launch()
+	}
+	for len(inflight) > 0 {
+		res := <-inflight[0]
+		inflight = inflight[1:]
+		if res.err != nil {
+			return res.err
+		}
+		if err := fn(res.page); err != nil {
+			return stopped(err)
+		}
+		launch()
+	}
+
+	return nil
+}
+
+func stopped(err error) error {
+	if errors.Is(err, StopPaging) {
+		return nil
+	}
+	return err
+}
+
+// Pages calls fn with every page of entries matching the query, in order
+func (s *EntriesService) Pages(ctx context.Context, query url.Values, opts *PageOptions, fn func(*Entries) error) error {
+	return paginate(ctx, query, opts, s.GetEntriesContext, func(p *Entries) int { return p.Total }, fn)
+}
+
+// Each calls fn with every entry matching the query, in order
+func (s *EntriesService) Each(ctx context.Context, query url.Values, opts *PageOptions, fn func(*Entry) error) error {
+	return s.Pages(ctx, query, opts, func(p *Entries) error {
+		for _, item := range p.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// All fetches every entry matching the query, merging the includes of the pages
+func (s *EntriesService) All(ctx context.Context, query url.Values, opts *PageOptions) (*Entries, error) {
+	res := &Entries{
+		Items: make([]*Entry, 0),
+	}
+	includes := newIncludeSet()
+	err := s.Pages(ctx, query, opts, func(p *Entries) error {
+		res.Items = append(res.Items, p.Items...)
+		includes.add(p.Includes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Total = len(res.Items)
+	res.Limit = res.Total
+	res.Includes = includes.include
+	return res, nil
+}
+
+// Pages calls fn with every page of assets matching the query, in order
+func (s *AssetsService) Pages(ctx context.Context, query url.Values, opts *PageOptions, fn func(*Assets) error) error {
+	return paginate(ctx, query, opts, s.GetAssetsContext, func(p *Assets) int { return p.Total }, fn)
+}
+
+// Each calls fn with every asset matching the query, in order
+func (s *AssetsService) Each(ctx context.Context, query url.Values, opts *PageOptions, fn func(*Asset) error) error {
+	return s.Pages(ctx, query, opts, func(p *Assets) error {
+		for _, item := range p.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// All fetches every asset matching the query
+func (s *AssetsService) All(ctx context.Context, query url.Values, opts *PageOptions) (*Assets, error) {
+	res := &Assets{
+		Items: make([]*Asset, 0),
+	}
+	err := s.Each(ctx, query, opts, func(a *Asset) error {
+		res.Items = append(res.Items, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Total = len(res.Items)
+	res.Limit = res.Total
+	return res, nil
+}
+
+// Pages calls fn with every page of content types matching the query, in order
+func (s *ContentTypesService) Pages(ctx context.Context, query url.Values, opts *PageOptions, fn func(*ContentTypes) error) error {
+	return paginate(ctx, query, opts, s.getTypesContext, func(p *ContentTypes) int { return p.Total }, fn)
+}
+
+// Each calls fn with every content type matching the query, in order
+func (s *ContentTypesService) Each(ctx context.Context, query url.Values, opts *PageOptions, fn func(*ContentType) error) error {
+	return s.Pages(ctx, query, opts, func(p *ContentTypes) error {
+		for _, item := range p.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// All fetches every content type matching the query
+func (s *ContentTypesService) All(ctx context.Context, query url.Values, opts *PageOptions) (*ContentTypes, error) {
+	res := &ContentTypes{
+		Items: make([]*ContentType, 0),
+	}
+	err := s.Each(ctx, query, opts, func(ct *ContentType) error {
+		res.Items = append(res.Items, ct)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Total = len(res.Items)
+	res.Limit = res.Total
+	return res, nil
+}
+
+// includeSet merges the includes of several pages without duplicates
+type includeSet struct {
+	include *Include
+	seen    map[string]bool
+}
+
+func newIncludeSet() *includeSet {
+	return &includeSet{
+		seen: make(map[string]bool),
+	}
+}
+
+func (s *includeSet) add(inc *Include) {
+	if inc == nil {
+		return
+	}
+	if s.include == nil {
+		s.include = &Include{}
+	}
+	for _, e := range inc.Entry {
+		if e.Sys != nil && !s.seen[ENTRY+e.Sys.ID] {
+			s.seen[ENTRY+e.Sys.ID] = true
+			s.include.Entry = append(s.include.Entry, e)
+		}
+	}
+	for _, a := range inc.Asset {
+		if a.Sys != nil && !s.seen[ASSET+a.Sys.ID] {
+			s.seen[ASSET+a.Sys.ID] = true
+			s.include.Asset = append(s.include.Asset, a)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package gontentful_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+	"github.com/moonwalker/gontentful/gontentfultest"
+)
+
+func addEntries(srv *gontentfultest.Server, n int) {
+	for i := 0; i < n; i++ {
+		srv.AddEntry(entry(fmt.Sprintf("e%02d", i), "page", gontentful.Fields{"title": localized(fmt.Sprint(i))}))
+	}
+}
+
+func TestPagesBoundaries(t *testing.T) {
+	for _, tc := range []struct {
+		entries int
+		pages   int
+	}{
+		{0, 1},
+		{5, 2},
+		{6, 2},
+		{7, 3},
+	} {
+		srv, client := newFake(t)
+		addEntries(srv, tc.entries)
+
+		ids := make([]string, 0)
+		pages := 0
+		query := url.Values{"order": []string{"sys.id"}}
+		err := client.Entries.Pages(context.Background(), query, &gontentful.PageOptions{Limit: 3, Concurrency: 2}, func(p *gontentful.Entries) error {
+			pages++
+			for _, e := range p.Items {
+				ids = append(ids, e.Sys.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pages != tc.pages || countRequests(srv, "/entries") != tc.pages {
+			t.Errorf("%d entries: got %d pages in %d requests, want %d", tc.entries, pages, countRequests(srv, "/entries"), tc.pages)
+		}
+		if len(ids) != tc.entries {
+			t.Fatalf("%d entries: got %d", tc.entries, len(ids))
+		}
+		for i, id := range ids {
+			if want := fmt.Sprintf("e%02d", i); id != want {
+				t.Errorf("%d entries: got %s at %d, want %s in order", tc.entries, id, i, want)
+			}
+		}
+	}
+}
+
+func TestPagesSkip(t *testing.T) {
+	srv, client := newFake(t)
+	addEntries(srv, 7)
+
+	res, err := client.Entries.All(context.Background(), url.Values{"order": []string{"sys.id"}, "skip": []string{"2"}}, &gontentful.PageOptions{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 5 || res.Items[0].Sys.ID != "e02" || res.Total != 5 {
+		t.Errorf("got %d entries from %v, want the 5 after the skipped ones", len(res.Items), res.Items[0].Sys.ID)
+	}
+}
+
+func TestEachStopPaging(t *testing.T) {
+	srv, client := newFake(t)
+	addEntries(srv, 7)
+
+	seen := 0
+	err := client.Entries.Each(context.Background(), url.Values{"order": []string{"sys.id"}}, &gontentful.PageOptions{Limit: 3, Concurrency: 1}, func(e *gontentful.Entry) error {
+		seen++
+		if e.Sys.ID == "e03" {
+			return gontentful.StopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got %v, want StopPaging to end without an error", err)
+	}
+	if seen != 4 {
+		t.Errorf("saw %d entries, want 4", seen)
+	}
+}
+
+func TestAssetsAll(t *testing.T) {
+	srv, client := newFake(t)
+	for i := 0; i < 4; i++ {
+		srv.AddAsset(&gontentful.Entry{Sys: &gontentful.Sys{ID: fmt.Sprintf("a%d", i)}, Fields: gontentful.Fields{"title": localized("a")}})
+	}
+
+	res, err := client.Assets.All(context.Background(), nil, &gontentful.PageOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 4 || countRequests(srv, "/assets") != 2 {
+		t.Errorf("got %d assets in %d requests", len(res.Items), countRequests(srv, "/assets"))
+	}
+}
@@ -39,6 +39,7 @@ func NewPGPublish(schemaName string, locales []*Locale, contentModel *ContentTyp
 	switch item.Sys.Type {
 	case ENTRY:
 		contentTypeColumns, columnReferences, localizedColumns := getContentTypeColumns(contentModel)
+		richTextColumns := getRichTextColumns(contentModel)
 		contentType := item.Sys.ContentType.Sys.ID
 		q.TableName = toSnakeCase(contentType)
 		for _, oLoc := range locales {
@@ -74,11 +75,14 @@ func NewPGPublish(schemaName string, locales []*Locale, contentModel *ContentTyp
 					if columnReferences[col] != "" {
 						appendPublishColCons(q, columnReferences[col], col, fieldValue, item.Sys.ID, id, loc)
 					}
-				} else if _, ok := columnReferences[col]; ok {
+					if richTextColumns[col] {
+						appendRichTextCons(q.ConTables, q.DeletedConTables, q.TableName, col, fieldValue, item.Sys.ID, id, loc, true)
+					}
+				} else if _, ok := columnReferences[col]; ok || richTextColumns[col] {
 					appendDeletedColCons(q, col, id)
 				}
 			}
-			q.Rows = append(q.Rows, newPGPublishRow(item.Sys, contentTypeColumns, fieldValues, loc, rowStatus))
+			q.Rows = append(q.Rows, newPGPublishRow(item.Sys, item.Metadata, contentTypeColumns, fieldValues, loc, rowStatus))
 		}
 	case ASSET:
 		q.TableName = ASSET_TABLE_NAME
@@ -101,7 +105,7 @@ func NewPGPublish(schemaName string, locales []*Locale, contentModel *ContentTyp
 				fieldValues["file_name"] = fmt.Sprintf("'%s'", file["fileName"])
 				fieldValues["content_type"] = fmt.Sprintf("'%s'", file["contentType"])
 			}
-			q.Rows = append(q.Rows, newPGPublishRow(item.Sys, assetColumns, fieldValues, strings.ToLower(oLoc.Code), rowStatus))
+			q.Rows = append(q.Rows, newPGPublishRow(item.Sys, item.Metadata, assetColumns, fieldValues, strings.ToLower(oLoc.Code), rowStatus))
 		}
 	}
 	return q
@@ -168,7 +172,7 @@ func (s *PGPublish) Render() (string, error) {
 	return buff.String(), nil
 }
 
-func newPGPublishRow(sys *Sys, fieldColumns []string, fieldValues map[string]interface{}, locale string, status string) *PGSyncRow {
+func newPGPublishRow(sys *Sys, metadata *Metadata, fieldColumns []string, fieldValues map[string]interface{}, locale string, status string) *PGSyncRow {
 	row := &PGSyncRow{
 		SysID:        sys.ID,
 		FieldColumns: fieldColumns,
@@ -178,6 +182,7 @@ func newPGPublishRow(sys *Sys, fieldColumns []string, fieldValues map[string]int
 		Version:      sys.Version,
 		CreatedAt:    sys.CreatedAt,
 		UpdatedAt:    sys.UpdatedAt,
+		Tags:         metadata.TagIDs(),
 	}
 	if sys.CreatedBy != nil {
 		row.CreatedBy = sys.CreatedBy.Sys.ID
@@ -16,7 +16,8 @@ INSERT INTO {{ $.SchemaName }}.{{ $.TableName }} (
 	_updated_at,
 	_updated_by,
 	_published_at,
-	_published_by
+	_published_by,
+	_tags
 ) VALUES (
 	'{{ .SysID }}_{{ .Locale }}',
 	'{{ .SysID }}',
@@ -31,7 +32,8 @@ INSERT INTO {{ $.SchemaName }}.{{ $.TableName }} (
 	to_timestamp('{{ .UpdatedAt }}','YYYY-MM-DDThh24:mi:ssZ'),
 	'{{ if not .UpdatedBy }}sync{{ else }}{{ .UpdatedBy }}{{ end }}',
 	{{ if .PublishedAt }}to_timestamp('{{ .PublishedAt }}','YYYY-MM-DDThh24:mi:ssZ'){{ else }}NULL{{ end }},
-	{{ if and .PublishedAt .PublishedBy }}'{{ .PublishedBy }}'{{ else }}NULL{{ end }}
+	{{ if and .PublishedAt .PublishedBy }}'{{ .PublishedBy }}'{{ else }}NULL{{ end }},
+	{{ .GetTags }}
 )
 ON CONFLICT (_id) DO UPDATE
 SET
@@ -44,7 +46,8 @@ SET
 	_updated_at = EXCLUDED._updated_at,
 	_updated_by = EXCLUDED._updated_by,
 	_published_at = EXCLUDED._published_at,
-	_published_by = EXCLUDED._published_by
+	_published_by = EXCLUDED._published_by,
+	_tags = EXCLUDED._tags
 ;
 {{- end -}}
 {{ range $tblidx, $tbl := .DeletedConTables }}
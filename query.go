@@ -0,0 +1,204 @@
+package gontentful
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query builds delivery api query parameters. The same query can be sent to
+// Contentful (Values) or run against the postgres mirror (PGQuery).
+// List values (In, NotIn, All) can not contain commas, Err reports those.
+//
+//	q := gontentful.NewQuery().
+//		ContentType("game").
+//		Equal("marketCode", "ROW").
+//		In("tags", "top", "new").
+//		Order("-fields.priority", "sys.id").
+//		Locale("en").
+//		Limit(12)
+//	entries, err := client.Entries.GetEntries(q.Values())
+type Query struct {
+	values url.Values
+	err    error
+}
+
+func NewQuery() *Query {
+	return &Query{
+		values: url.Values{},
+	}
+}
+
+// ContentType restricts the query to entries of a content type
+func (q *Query) ContentType(id string) *Query {
+	q.values.Set("content_type", id)
+	return q
+}
+
+// Equal matches field == value, fields without a fields., sys. or metadata. prefix are entry fields
+func (q *Query) Equal(field string, value interface{}) *Query {
+	return q.filter(field, "", value)
+}
+
+func (q *Query) NotEqual(field string, value interface{}) *Query {
+	return q.filter(field, "ne", value)
+}
+
+// In matches any of the values
+func (q *Query) In(field string, values ...interface{}) *Query {
+	return q.filter(field, "in", values...)
+}
+
+func (q *Query) NotIn(field string, values ...interface{}) *Query {
+	return q.filter(field, "nin", values...)
+}
+
+// All matches array fields containing every value
+func (q *Query) All(field string, values ...interface{}) *Query {
+	return q.filter(field, "all", values...)
+}
+
+// Tags matches entries or assets tagged with any of the tag ids
+func (q *Query) Tags(ids ...string) *Query {
+	return q.In(tagsQueryField, stringValues(ids)...)
+}
+
+// AllTags matches entries or assets tagged with every tag id
+func (q *Query) AllTags(ids ...string) *Query {
+	return q.All(tagsQueryField, stringValues(ids)...)
+}
+
+func (q *Query) Exists(field string, exists bool) *Query {
+	return q.filter(field, "exists", exists)
+}
+
+func (q *Query) LessThan(field string, value interface{}) *Query {
+	return q.filter(field, "lt", value)
+}
+
+func (q *Query) LessThanOrEqual(field string, value interface{}) *Query {
+	return q.filter(field, "lte", value)
+}
+
+func (q *Query) GreaterThan(field string, value interface{}) *Query {
+	return q.filter(field, "gt", value)
+}
+
+func (q *Query) GreaterThanOrEqual(field string, value interface{}) *Query {
+	return q.filter(field, "gte", value)
+}
+
+// Match is a full text search on the field
+func (q *Query) Match(field string, text string) *Query {
+	return q.filter(field, "match", text)
+}
+
+// Near orders by distance from a point of a Location field, it has no postgres equivalent
+func (q *Query) Near(field string, lat float64, lon float64) *Query {
+	return q.filter(field, "near", lat, lon)
+}
+
+// Order sorts by the given fields, prefix with - for descending order
+func (q *Query) Order(fields ...string) *Query {
+	q.values.Set("order", strings.Join(fields, ","))
+	return q
+}
+
+// Select limits the returned fields, e.g. sys.id, fields.slug
+func (q *Query) Select(fields ...string) *Query {
+	q.values.Set("select", strings.Join(fields, ","))
+	return q
+}
+
+// Include sets how many levels of links are included
+func (q *Query) Include(depth int) *Query {
+	q.values.Set("include", strconv.Itoa(depth))
+	return q
+}
+
+// Locale sets the locale of the fields, * returns every locale
+func (q *Query) Locale(code string) *Query {
+	q.values.Set("locale", code)
+	return q
+}
+
+func (q *Query) Skip(skip int) *Query {
+	q.values.Set("skip", strconv.Itoa(skip))
+	return q
+}
+
+func (q *Query) Limit(limit int) *Query {
+	q.values.Set("limit", strconv.Itoa(limit))
+	return q
+}
+
+// Values returns a copy of the query parameters
+func (q *Query) Values() url.Values {
+	res := url.Values{}
+	for k, v := range q.values {
+		res[k] = append([]string(nil), v...)
+	}
+	return res
+}
+
+func (q *Query) String() string {
+	return q.values.Encode()
+}
+
+// PGQuery returns the same query for the postgres mirror
+func (q *Query) PGQuery(schemaName string, defaultLocale string) *PGQuery {
+	return ParsePGQuery(schemaName, defaultLocale, q.Values())
+}
+
+// Err returns the first invalid filter of the query, a list value containing a comma
+// can not be told apart from two values by Contentful or the postgres mirror
+func (q *Query) Err() error {
+	return q.err
+}
+
+func (q *Query) filter(field string, operator string, values ...interface{}) *Query {
+	key := queryField(field)
+	if operator != "" {
+		key = fmt.Sprintf("%s[%s]", key, operator)
+	}
+	vals := make([]string, len(values))
+	for i, v := range values {
+		vals[i] = queryValue(v)
+		if q.err == nil && isListFilter(key) && strings.Contains(vals[i], ",") {
+			q.err = fmt.Errorf("%s: value %q contains a comma", key, vals[i])
+		}
+	}
+	q.values.Set(key, strings.Join(vals, ","))
+	return q
+}
+
+func queryField(field string) string {
+	if strings.HasPrefix(field, "fields.") || strings.HasPrefix(field, "sys.") || strings.HasPrefix(field, "metadata.") {
+		return field
+	}
+	return "fields." + field
+}
+
+func queryValue(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case time.Time:
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+func stringValues(values []string) []interface{} {
+	res := make([]interface{}, len(values))
+	for i, v := range values {
+		res[i] = v
+	}
+	return res
+}
@@ -107,16 +107,18 @@ func createFilters(filters url.Values) *[]string {
 	if filters != nil && len(filters) > 0 {
 		filterFields := make([]string, 0)
 		for key, values := range filters {
-			vals := ""
+			vals := make([]string, 0)
 			for _, val := range values {
-				for i, v := range strings.Split(val, ",") {
-					if i > 0 {
-						vals = vals + ","
-					}
-					vals = vals + formatValue(v)
+				// only the list operators take comma separated values, like the delivery api
+				if !isListFilter(key) {
+					vals = append(vals, formatValue(val))
+					continue
+				}
+				for _, v := range strings.Split(val, ",") {
+					vals = append(vals, formatValue(v))
 				}
 			}
-			f := getFilterFormat(key, vals, values)
+			f := getFilterFormat(key, strings.Join(vals, ","), values)
 			if f != "" {
 				filterFields = append(filterFields, f)
 			}
@@ -138,6 +140,10 @@ func getFilterFormat(key string, value string, values []string) string {
 		f = strings.Replace(f, fmt.Sprintf("[%s]", c), "", 1)
 	}
 
+	if f == tagsQueryField {
+		return getTagsFilterFormat(c, values)
+	}
+
 	f = formatField(f)
 	if f == "" {
 		return f
@@ -166,6 +172,9 @@ func getFilterFormat(key string, value string, values []string) string {
 	case "ne":
 		return fmt.Sprintf("%s IS DISTINCT FROM %s", col, value)
 	case "exists":
+		if len(values) > 0 && values[0] == "false" {
+			return fmt.Sprintf("%s IS NULL", col)
+		}
 		return fmt.Sprintf("%s IS NOT NULL", col)
 	case "lt":
 		return fmt.Sprintf("%s < %s", col, value)
@@ -191,6 +200,31 @@ func getFilterFormat(key string, value string, values []string) string {
 	return ""
 }
 
+// getTagsFilterFormat filters on the tag ids array, tag ids are always text
+func getTagsFilterFormat(c string, values []string) string {
+	tags := make([]string, 0)
+	for _, val := range values {
+		for _, v := range strings.Split(val, ",") {
+			tags = append(tags, fmt.Sprintf("''%s''", strings.ReplaceAll(v, "'", "''''")))
+		}
+	}
+	arr := fmt.Sprintf("ARRAY[%s]::text[]", strings.Join(tags, ","))
+	switch c {
+	case "", "all":
+		return fmt.Sprintf("%s @> %s", TAGS_COLUMN, arr)
+	case "in":
+		return fmt.Sprintf("%s && %s", TAGS_COLUMN, arr)
+	case "nin":
+		return fmt.Sprintf("NOT (%s && %s)", TAGS_COLUMN, arr)
+	case "exists":
+		if len(values) > 0 && values[0] == "false" {
+			return fmt.Sprintf("cardinality(%s) = 0", TAGS_COLUMN)
+		}
+		return fmt.Sprintf("cardinality(%s) > 0", TAGS_COLUMN)
+	}
+	return ""
+}
+
 func formatValue(s string) string {
 	if s == "true" || s == "false" {
 		return fmt.Sprintf("%s", s)
@@ -201,7 +235,11 @@ func formatValue(s string) string {
 		return fmt.Sprintf("%f", f)
 	}
 
-	return fmt.Sprintf("''%s''", s)
+	return fmt.Sprintf("''%s''", strings.ReplaceAll(s, "'", "''''"))
+}
+
+func isListFilter(key string) bool {
+	return strings.HasSuffix(key, "[in]") || strings.HasSuffix(key, "[nin]") || strings.HasSuffix(key, "[all]")
 }
 
 func formatField(f string) string {
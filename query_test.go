@@ -0,0 +1,67 @@
+package gontentful_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func pgFilters(q *gontentful.Query) []string {
+	pg := q.PGQuery("schema", "en")
+	if pg.Filters == nil {
+		return nil
+	}
+	res := append([]string(nil), *pg.Filters...)
+	sort.Strings(res)
+	return res
+}
+
+func TestQueryPGFilters(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		query *gontentful.Query
+		want  string
+	}{
+		{"equal", gontentful.NewQuery().Equal("marketCode", "ROW"), "market_code = ''ROW''"},
+		{"equal comma", gontentful.NewQuery().Equal("name", "a,b"), "name = ''a,b''"},
+		{"equal quote", gontentful.NewQuery().Equal("name", "o'neil"), "name = ''o''''neil''"},
+		{"equal number", gontentful.NewQuery().Equal("priority", 2), "priority = 2.000000"},
+		{"not equal", gontentful.NewQuery().NotEqual("sys.id", "a"), "_sys_id IS DISTINCT FROM ''a''"},
+		{"in", gontentful.NewQuery().In("slug", "a", "b"), "slug = ANY(ARRAY[''a'',''b''])"},
+		{"not in", gontentful.NewQuery().NotIn("slug", "a", "b"), "slug != ALL(ARRAY[''a'',''b''])"},
+		{"all", gontentful.NewQuery().All("labels", "a", "b"), "labels @> ARRAY[''a'',''b'']"},
+		{"exists", gontentful.NewQuery().Exists("logo", true), "logo IS NOT NULL"},
+		{"not exists", gontentful.NewQuery().Exists("logo", false), "logo IS NULL"},
+		{"range", gontentful.NewQuery().GreaterThanOrEqual("priority", 1), "priority >= 1.000000"},
+	} {
+		if err := tc.query.Err(); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		got := pgFilters(tc.query)
+		if len(got) != 1 || got[0] != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestQueryPGQuery(t *testing.T) {
+	q := gontentful.NewQuery().ContentType("gameInfo").Equal("marketCode", "ROW").Order("-fields.priority").Locale("de").Skip(10).Limit(5)
+	pg := q.PGQuery("schema", "en")
+	if pg.TableName != "game_info" || pg.Skip != 10 || pg.Limit != 5 || pg.Filters == nil || len(*pg.Filters) != 1 {
+		t.Errorf("got %+v", pg)
+	}
+	// the builder keeps its own values
+	if q.Values().Get("content_type") != "gameInfo" {
+		t.Error("PGQuery changed the query")
+	}
+}
+
+func TestQueryRejectsCommaInList(t *testing.T) {
+	if err := gontentful.NewQuery().In("slug", "a,b", "c").Err(); err == nil {
+		t.Error("expected an error for a list value with a comma")
+	}
+	if err := gontentful.NewQuery().Equal("slug", "a,b").Err(); err != nil {
+		t.Errorf("got %v for an equal value with a comma", err)
+	}
+}
@@ -0,0 +1,116 @@
+package gontentful
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Default request rates per second of the Contentful APIs
+const (
+	CdnRateLimit     = 55
+	PreviewRateLimit = 14
+	CmaRateLimit     = 7
+)
+
+// RateLimiter is a token bucket limiter shared by every goroutine using the
+// same Client (or by several clients using the same token), so callers are
+// throttled before Contentful starts answering with 429s.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimiterStats
+}
+
+// RateLimiterStats reports how much the limiter slowed its callers down
+type RateLimiterStats struct {
+	Requests int64
+	Waits    int64
+	Waited   time.Duration
+}
+
+// NewRateLimiter allows perSecond requests on average with bursts of up to burst requests
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+
+	d := l.reserve()
+	if d <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, d); err != nil {
+		l.cancel()
+		return err
+	}
+
+	l.mu.Lock()
+	l.stats.Waits++
+	l.stats.Waited += d
+	l.mu.Unlock()
+
+	return nil
+}
+
+// Stats returns a snapshot of the limiter metrics
+func (l *RateLimiter) Stats() RateLimiterStats {
+	if l == nil {
+		return RateLimiterStats{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// reserve takes a token and returns how long the caller has to wait for it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.stats.Requests++
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a reserved token that was not used
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+	l.stats.Requests--
+}
+
+func (c *Client) cdaLimiter() *RateLimiter {
+	if c.Options.UsePreview {
+		return c.Options.PreviewLimiter
+	}
+	return c.Options.CdnLimiter
+}
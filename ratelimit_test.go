@@ -0,0 +1,68 @@
+package gontentful_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := gontentful.NewRateLimiter(100, 3)
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s := l.Stats(); s.Waits != 0 {
+		t.Fatalf("waited %d times within the burst", s.Waits)
+	}
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 5*time.Millisecond {
+		t.Errorf("waited %s past the burst, want about 10ms", d)
+	}
+	if s := l.Stats(); s.Requests != 4 || s.Waits != 1 {
+		t.Errorf("got stats %+v", s)
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	l := gontentful.NewRateLimiter(100, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if s := l.Stats(); s.Waits != 0 {
+		t.Errorf("waited %d times after the bucket refilled", s.Waits)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := gontentful.NewRateLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want the context error", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("waited %s for a token after the context was done", d)
+	}
+	// the canceled wait gives its token back
+	if s := l.Stats(); s.Requests != 1 || s.Waits != 0 {
+		t.Errorf("got stats %+v", s)
+	}
+}
@@ -0,0 +1,326 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	RELEASE        = "Release"
+	RELEASE_ACTION = "ReleaseAction"
+
+	ReleaseActionPublish   = "publish"
+	ReleaseActionUnpublish = "unpublish"
+	ReleaseActionValidate  = "validate"
+
+	ReleaseActionStatusInProgress = "inProgress"
+	ReleaseActionStatusSucceeded  = "succeeded"
+	ReleaseActionStatusFailed     = "failed"
+
+	defaultReleaseTimeout = 5 * time.Minute
+)
+
+type ReleaseEntities struct {
+	Sys   *Sys     `json:"sys"`
+	Items []*Entry `json:"items"`
+}
+
+type Release struct {
+	Sys      *Sys             `json:"sys,omitempty"`
+	Title    string           `json:"title"`
+	Entities *ReleaseEntities `json:"entities"`
+}
+
+type Releases struct {
+	Sys   *Sys       `json:"sys"`
+	Limit int        `json:"limit"`
+	Items []*Release `json:"items"`
+}
+
+// ReleaseActionSys carries the status as a string, unlike the status link of environments
+type ReleaseActionSys struct {
+	Sys
+	Status  string `json:"status,omitempty"`
+	Release *Entry `json:"release,omitempty"`
+}
+
+type ReleaseAction struct {
+	Sys    *ReleaseActionSys `json:"sys"`
+	Action string            `json:"action"`
+	Error  *ActionError      `json:"error,omitempty"`
+}
+
+type ReleaseActions struct {
+	Sys   *Sys             `json:"sys"`
+	Limit int              `json:"limit"`
+	Items []*ReleaseAction `json:"items"`
+}
+
+// ActionError is the error of a failed release or scheduled action, details list the failing entities
+type ActionError struct {
+	Sys     *Sys            `json:"sys"`
+	Message string          `json:"message,omitempty"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+func (e *ActionError) Error() string {
+	id := ""
+	if e.Sys != nil {
+		id = e.Sys.ID
+	}
+	if e.Message == "" {
+		return id
+	}
+	return fmt.Sprintf("%s: %s", id, e.Message)
+}
+
+// Status returns inProgress, succeeded or failed
+func (a *ReleaseAction) Status() string {
+	if a.Sys == nil {
+		return ""
+	}
+	return a.Sys.Status
+}
+
+// ReleasesService groups entries and assets to publish or unpublish as a unit on the CMA
+type ReleasesService service
+
+func (s *ReleasesService) GetReleases(query url.Values) (*Releases, error) {
+	return s.GetReleasesContext(context.Background(), query)
+}
+
+func (s *ReleasesService) GetReleasesContext(ctx context.Context, query url.Values) (*Releases, error) {
+	path := fmt.Sprintf(pathReleases, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	res := &Releases{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *ReleasesService) GetSingle(releaseId string) (*Release, error) {
+	return s.GetSingleContext(context.Background(), releaseId)
+}
+
+func (s *ReleasesService) GetSingleContext(ctx context.Context, releaseId string) (*Release, error) {
+	path := fmt.Sprintf(pathRelease, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalRelease(data)
+}
+
+// Create creates a release of the linked entries and assets
+func (s *ReleasesService) Create(title string, entities ...Link) (*Release, error) {
+	return s.CreateContext(context.Background(), title, entities...)
+}
+
+func (s *ReleasesService) CreateContext(ctx context.Context, title string, entities ...Link) (*Release, error) {
+	body, err := releaseBody(title, entities)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathReleases, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalRelease(data)
+}
+
+// Update replaces the title and entities of the release
+func (s *ReleasesService) Update(version string, releaseId string, title string, entities ...Link) (*Release, error) {
+	return s.UpdateContext(context.Background(), version, releaseId, title, entities...)
+}
+
+func (s *ReleasesService) UpdateContext(ctx context.Context, version string, releaseId string, title string, entities ...Link) (*Release, error) {
+	body, err := releaseBody(title, entities)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathRelease, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalRelease(data)
+}
+
+func (s *ReleasesService) Delete(releaseId string) error {
+	return s.DeleteContext(context.Background(), releaseId)
+}
+
+func (s *ReleasesService) DeleteContext(ctx context.Context, releaseId string) error {
+	path := fmt.Sprintf(pathRelease, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId)
+	_, err := s.client.delete(ctx, path)
+	return err
+}
+
+// Publish starts publishing every entity of the release, the returned action runs asynchronously
+func (s *ReleasesService) Publish(releaseId string, version string) (*ReleaseAction, error) {
+	return s.PublishContext(context.Background(), releaseId, version)
+}
+
+func (s *ReleasesService) PublishContext(ctx context.Context, releaseId string, version string) (*ReleaseAction, error) {
+	path := fmt.Sprintf(pathReleasePublished, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId)
+	data, err := s.client.put(ctx, path, nil, withVersion(version))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalReleaseAction(data)
+}
+
+// UnPublish starts unpublishing every entity of the release
+func (s *ReleasesService) UnPublish(releaseId string, version string) (*ReleaseAction, error) {
+	return s.UnPublishContext(context.Background(), releaseId, version)
+}
+
+func (s *ReleasesService) UnPublishContext(ctx context.Context, releaseId string, version string) (*ReleaseAction, error) {
+	path := fmt.Sprintf(pathReleasePublished, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId)
+	data, err := s.client.delete(ctx, path, withVersion(version))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalReleaseAction(data)
+}
+
+// Validate starts validating the release for publishing
+func (s *ReleasesService) Validate(releaseId string) (*ReleaseAction, error) {
+	return s.ValidateContext(context.Background(), releaseId)
+}
+
+func (s *ReleasesService) ValidateContext(ctx context.Context, releaseId string) (*ReleaseAction, error) {
+	body, err := json.Marshal(map[string]string{"action": ReleaseActionPublish})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathReleaseValidate, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId)
+	data, err := s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalReleaseAction(data)
+}
+
+func (s *ReleasesService) GetAction(releaseId string, actionId string) (*ReleaseAction, error) {
+	return s.GetActionContext(context.Background(), releaseId, actionId)
+}
+
+func (s *ReleasesService) GetActionContext(ctx context.Context, releaseId string, actionId string) (*ReleaseAction, error) {
+	path := fmt.Sprintf(pathReleaseAction, s.client.Options.SpaceID, s.client.Options.EnvironmentID, releaseId, actionId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalReleaseAction(data)
+}
+
+// GetActions lists the release actions of the environment, e.g. filtered by sys.release.sys.id[in]
+func (s *ReleasesService) GetActions(query url.Values) (*ReleaseActions, error) {
+	return s.GetActionsContext(context.Background(), query)
+}
+
+func (s *ReleasesService) GetActionsContext(ctx context.Context, query url.Values) (*ReleaseActions, error) {
+	path := fmt.Sprintf(pathReleaseActions, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	res := &ReleaseActions{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// WaitAction polls the action until it succeeds or fails, a failed action is returned with its error
+func (s *ReleasesService) WaitAction(action *ReleaseAction, timeout time.Duration) (*ReleaseAction, error) {
+	return s.WaitActionContext(context.Background(), action, timeout)
+}
+
+func (s *ReleasesService) WaitActionContext(ctx context.Context, action *ReleaseAction, timeout time.Duration) (*ReleaseAction, error) {
+	if action == nil || action.Sys == nil || action.Sys.Release == nil || action.Sys.Release.Sys == nil {
+		return nil, fmt.Errorf("invalid release action")
+	}
+	if timeout <= 0 {
+		timeout = defaultReleaseTimeout
+	}
+	releaseId := action.Sys.Release.Sys.ID
+	actionId := action.Sys.ID
+	res := action
+	err := poll(ctx, timeout, func(ctx context.Context) (bool, error) {
+		if res.Status() != ReleaseActionStatusInProgress && res.Status() != "" {
+			return true, nil
+		}
+		var err error
+		res, err = s.GetActionContext(ctx, releaseId, actionId)
+		if err != nil {
+			return false, err
+		}
+		return res.Status() != ReleaseActionStatusInProgress, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("release %s %s did not finish: %w", releaseId, action.Action, err)
+	}
+	if res.Status() == ReleaseActionStatusFailed {
+		if res.Error != nil {
+			return res, fmt.Errorf("release %s %s failed: %w", releaseId, res.Action, res.Error)
+		}
+		return res, fmt.Errorf("release %s %s failed", releaseId, res.Action)
+	}
+	return res, nil
+}
+
+func releaseBody(title string, entities []Link) ([]byte, error) {
+	items := make([]*Entry, 0, len(entities))
+	for _, l := range entities {
+		items = append(items, l.entry())
+	}
+	return json.Marshal(&Release{
+		Title: title,
+		Entities: &ReleaseEntities{
+			Sys:   &Sys{Type: "Array"},
+			Items: items,
+		},
+	})
+}
+
+// entry returns the link object of the link
+func (l Link) entry() *Entry {
+	return &Entry{
+		Sys: &Sys{
+			Type:     LINK,
+			LinkType: l.LinkType,
+			ID:       l.ID,
+		},
+	}
+}
+
+func unmarshalRelease(data []byte) (*Release, error) {
+	res := &Release{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func unmarshalReleaseAction(data []byte) (*ReleaseAction, error) {
+	res := &ReleaseAction{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
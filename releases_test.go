@@ -0,0 +1,143 @@
+package gontentful_test
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestReleasePublish(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("game1", "game", gontentful.Fields{"name": localized("Starburst")}))
+	srv.AddEntry(entry("game2", "game", gontentful.Fields{"name": localized("Gonzo")}))
+	for _, id := range []string{"game1", "game2"} {
+		_, err := client.Entries.Modify(id, func(e *gontentful.Entry) error {
+			e.Fields["slug"] = localized(id)
+			return nil
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	release, err := client.Releases.Create("launch", gontentful.Link{LinkType: gontentful.ENTRY, ID: "game1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	release, err = client.Releases.Update(strconv.Itoa(release.Sys.Version), release.Sys.ID, "launch",
+		gontentful.Link{LinkType: gontentful.ENTRY, ID: "game1"},
+		gontentful.Link{LinkType: gontentful.ENTRY, ID: "game2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(release.Entities.Items) != 2 || release.Sys.Version != 2 {
+		t.Fatalf("got %+v", release)
+	}
+
+	action, err := client.Releases.Publish(release.Sys.ID, strconv.Itoa(release.Sys.Version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Status() != gontentful.ReleaseActionStatusInProgress {
+		t.Errorf("got status %s, want in progress", action.Status())
+	}
+	action, err = client.Releases.WaitAction(action, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Status() != gontentful.ReleaseActionStatusSucceeded || action.Action != gontentful.ReleaseActionPublish {
+		t.Errorf("got %s %s", action.Action, action.Status())
+	}
+	for _, id := range []string{"game1", "game2"} {
+		e := srv.Entry(id)
+		if e.Sys.PublishedVersion != e.Sys.Version-1 {
+			t.Errorf("%s: published version %d of %d, want the latest", id, e.Sys.PublishedVersion, e.Sys.Version)
+		}
+	}
+
+	actions, err := client.Releases.GetActions(url.Values{"sys.release.sys.id[in]": []string{release.Sys.ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions.Items) != 1 || actions.Items[0].Sys.ID != action.Sys.ID {
+		t.Errorf("got %d actions", len(actions.Items))
+	}
+}
+
+func TestReleasePublishFails(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("game1", "game", gontentful.Fields{"name": localized("Starburst")}))
+	_, err := client.Entries.Modify("game1", func(e *gontentful.Entry) error {
+		e.Fields["name"] = localized("Starburst XXXtreme")
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := client.Releases.Create("launch",
+		gontentful.Link{LinkType: gontentful.ENTRY, ID: "game1"},
+		gontentful.Link{LinkType: gontentful.ENTRY, ID: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err := client.Releases.Publish(release.Sys.ID, strconv.Itoa(release.Sys.Version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	action, err = client.Releases.WaitAction(action, time.Second)
+	var actionErr *gontentful.ActionError
+	if !errors.As(err, &actionErr) {
+		t.Fatalf("got %v, want an action error", err)
+	}
+	if action.Status() != gontentful.ReleaseActionStatusFailed || len(actionErr.Details) == 0 {
+		t.Errorf("got %s with %s", action.Status(), actionErr.Details)
+	}
+	// releases publish all of their entities or none
+	if e := srv.Entry("game1"); e.Sys.PublishedVersion == e.Sys.Version-1 {
+		t.Error("game1 was published by a failed release")
+	}
+}
+
+func TestReleaseVersionAndDelete(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("game1", "game", gontentful.Fields{"name": localized("Starburst")}))
+
+	release, err := client.Releases.Create("launch", gontentful.Link{LinkType: gontentful.ENTRY, ID: "game1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mismatch gontentful.VersionMismatchError
+	if _, err := client.Releases.Update("5", release.Sys.ID, "renamed"); !errors.As(err, &mismatch) {
+		t.Errorf("update: got %v, want a version mismatch", err)
+	}
+	if _, err := client.Releases.Publish(release.Sys.ID, ""); !errors.As(err, &mismatch) {
+		t.Errorf("publish: got %v, want a version mismatch", err)
+	}
+
+	action, err := client.Releases.UnPublish(release.Sys.ID, strconv.Itoa(release.Sys.Version))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Releases.WaitAction(action, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if countRequests(srv, "/releases/"+release.Sys.ID+"/actions/") == 0 {
+		t.Error("the action was not polled")
+	}
+	if _, err := client.Entries.GetSingle("game1"); err == nil {
+		t.Error("game1 is still published")
+	}
+
+	if err := client.Releases.Delete(release.Sys.ID); err != nil {
+		t.Fatal(err)
+	}
+	var notFound gontentful.NotFoundError
+	if _, err := client.Releases.GetSingle(release.Sys.ID); !errors.As(err, &notFound) {
+		t.Errorf("got %v, want not found", err)
+	}
+}
@@ -0,0 +1,141 @@
+package gontentful
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	headerRateLimitReset = "X-Contentful-RateLimit-Reset"
+)
+
+// RetryPolicy controls how failed requests are retried by the Client.
+// Rate limited requests (429) are retried for every method, honoring the
+// X-Contentful-RateLimit-Reset header when present. Gateway errors
+// (502, 503, 504) and network errors are only retried for idempotent methods.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one,
+	// values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled on each attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and the rate limit reset wait.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of the backoff that is randomized.
+	Jitter float64
+}
+
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.5,
+}
+
+var NoRetryPolicy = &RetryPolicy{
+	MaxAttempts: 1,
+}
+
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.Options.Retry != nil {
+		return c.Options.Retry
+	}
+	return DefaultRetryPolicy
+}
+
+func (p *RetryPolicy) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if res == nil {
+		// network errors
+		return isIdempotent(req.Method) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// delay returns how long to wait before the given (1 based) retry attempt
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		if secs, err := strconv.Atoi(res.Header.Get(headerRateLimitReset)); err == nil && secs >= 0 {
+			return p.cap(time.Duration(secs) * time.Second)
+		}
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	d = p.cap(d)
+
+	if p.Jitter > 0 && d > 0 {
+		j := time.Duration(float64(d) * p.Jitter)
+		d = d - j + time.Duration(rand.Int63n(int64(j)+1))
+	}
+	return d
+}
+
+func (p *RetryPolicy) cap(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// setGetBody makes the request body replayable, so it can be re-sent on retry.
+// http.NewRequest already does this for the bytes and strings readers,
+// other seekable bodies (e.g. files) are rewound to their start and are left
+// open for the caller to close.
+func setGetBody(req *http.Request, body io.Reader) {
+	if body == nil || req.GetBody != nil {
+		return
+	}
+	if rs, ok := body.(io.ReadSeeker); ok {
+		start, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return
+		}
+		req.Body = io.NopCloser(rs)
+		req.GetBody = func() (io.ReadCloser, error) {
+			if _, err := rs.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return io.NopCloser(rs), nil
+		}
+	}
+}
+
+// rewind prepares a request to be sent again, it reports false if the body can not be replayed
+func rewind(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
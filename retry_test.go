@@ -0,0 +1,111 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// retryServer answers with the given statuses in turn, then with 200
+func retryServer(t *testing.T, statuses ...int) (*Client, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	bodies := make([]string, 0)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if n := len(bodies); n <= len(statuses) {
+			w.Header().Set(headerRateLimitReset, "0")
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	c := NewClient(&ClientOptions{
+		SpaceID:  "space",
+		CdnURL:   host,
+		CmaURL:   host,
+		CdnToken: "token",
+		CmaToken: "token",
+		Retry:    &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+	})
+	c.client = srv.Client()
+	return c, &bodies
+}
+
+func TestRetryRateLimited(t *testing.T) {
+	c, bodies := retryServer(t, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	if _, err := c.get(context.Background(), "/spaces/space/entries", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("made %d requests, want 3", len(*bodies))
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	c, bodies := retryServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	if _, err := c.get(context.Background(), "/spaces/space/entries", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("made %d requests, want 3", len(*bodies))
+	}
+}
+
+func TestRetrySkipsNonIdempotent(t *testing.T) {
+	c, bodies := retryServer(t, http.StatusServiceUnavailable)
+	if _, err := c.post(context.Background(), "/spaces/space/entries", strings.NewReader(`{}`)); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("made %d requests for a post, want 1", len(*bodies))
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	c, bodies := retryServer(t, http.StatusNotFound)
+	if _, err := c.get(context.Background(), "/spaces/space/entries/missing", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("made %d requests for a 404, want 1", len(*bodies))
+	}
+}
+
+func TestRetryRewindsBody(t *testing.T) {
+	c, bodies := retryServer(t, http.StatusServiceUnavailable)
+	// a seekable body net/http can not replay by itself
+	body := struct{ io.ReadSeeker }{bytes.NewReader([]byte(`{"fields":{}}`))}
+	if _, err := c.put(context.Background(), "/spaces/space/entries/a", body); err != nil {
+		t.Fatal(err)
+	}
+	if len(*bodies) != 2 || (*bodies)[0] != (*bodies)[1] {
+		t.Errorf("got bodies %q, want the same body twice", *bodies)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+		if got := p.delay(attempt, nil); got != want {
+			t.Errorf("attempt %d: got %s, want %s", attempt, got, want)
+		}
+	}
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	res.Header.Set(headerRateLimitReset, "3")
+	if got := p.delay(1, res); got != 3*time.Second {
+		t.Errorf("got %s, want the rate limit reset", got)
+	}
+}
@@ -0,0 +1,162 @@
+package gontentful
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	RICH_TEXT = "RichText"
+
+	RichTextDocument           = "document"
+	RichTextParagraph          = "paragraph"
+	RichTextHeading1           = "heading-1"
+	RichTextHeading2           = "heading-2"
+	RichTextHeading3           = "heading-3"
+	RichTextHeading4           = "heading-4"
+	RichTextHeading5           = "heading-5"
+	RichTextHeading6           = "heading-6"
+	RichTextOrderedList        = "ordered-list"
+	RichTextUnorderedList      = "unordered-list"
+	RichTextListItem           = "list-item"
+	RichTextHR                 = "hr"
+	RichTextQuote              = "blockquote"
+	RichTextTable              = "table"
+	RichTextTableRow           = "table-row"
+	RichTextTableCell          = "table-cell"
+	RichTextTableHeaderCell    = "table-header-cell"
+	RichTextEmbeddedEntryBlock = "embedded-entry-block"
+	RichTextEmbeddedAssetBlock = "embedded-asset-block"
+	RichTextEmbeddedEntry      = "embedded-entry-inline"
+	RichTextHyperlink          = "hyperlink"
+	RichTextEntryHyperlink     = "entry-hyperlink"
+	RichTextAssetHyperlink     = "asset-hyperlink"
+	RichTextText               = "text"
+
+	RichTextMarkBold          = "bold"
+	RichTextMarkItalic        = "italic"
+	RichTextMarkUnderline     = "underline"
+	RichTextMarkCode          = "code"
+	RichTextMarkSuperscript   = "superscript"
+	RichTextMarkSubscript     = "subscript"
+	RichTextMarkStrikethrough = "strikethrough"
+)
+
+// RichTextNode is a node of a Rich Text document, the document itself is the root node
+type RichTextNode struct {
+	NodeType string          `json:"nodeType"`
+	Data     RichTextData    `json:"data"`
+	Content  []*RichTextNode `json:"content"`
+	// Value and Marks are only set on text nodes
+	Value string          `json:"value"`
+	Marks []*RichTextMark `json:"marks"`
+}
+
+// RichTextData holds the link target of embedded and hyperlink nodes
+type RichTextData struct {
+	Target *Entry `json:"target,omitempty"`
+	URI    string `json:"uri,omitempty"`
+}
+
+type RichTextMark struct {
+	Type string `json:"type"`
+}
+
+// ParseRichText decodes a Rich Text field value, a decoded json map, raw json or a json string
+func ParseRichText(value interface{}) (*RichTextNode, error) {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case *RichTextNode:
+		return v, nil
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+	doc := &RichTextNode{}
+	err := json.Unmarshal(data, doc)
+	if err != nil {
+		return nil, err
+	}
+	if doc.NodeType == "" {
+		return nil, fmt.Errorf("invalid rich text: missing nodeType")
+	}
+	return doc, nil
+}
+
+// MarshalJSON writes the fields the management api expects for the node type
+func (n *RichTextNode) MarshalJSON() ([]byte, error) {
+	if n.NodeType == RichTextText {
+		marks := n.Marks
+		if marks == nil {
+			marks = make([]*RichTextMark, 0)
+		}
+		return json.Marshal(struct {
+			NodeType string          `json:"nodeType"`
+			Value    string          `json:"value"`
+			Marks    []*RichTextMark `json:"marks"`
+			Data     RichTextData    `json:"data"`
+		}{n.NodeType, n.Value, marks, n.Data})
+	}
+	content := n.Content
+	if content == nil {
+		content = make([]*RichTextNode, 0)
+	}
+	return json.Marshal(struct {
+		NodeType string          `json:"nodeType"`
+		Data     RichTextData    `json:"data"`
+		Content  []*RichTextNode `json:"content"`
+	}{n.NodeType, n.Data, content})
+}
+
+// Walk calls fn with the node and its descendants depth first, returning false skips the children
+func (n *RichTextNode) Walk(fn func(*RichTextNode) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	for _, c := range n.Content {
+		c.Walk(fn)
+	}
+}
+
+// Links returns the embedded and hyperlinked entries and assets in document order, without duplicates
+func (n *RichTextNode) Links() []*Entry {
+	links := make([]*Entry, 0)
+	seen := make(map[string]bool)
+	n.Walk(func(node *RichTextNode) bool {
+		t := node.Data.Target
+		if t == nil || t.Sys == nil || t.Sys.ID == "" {
+			return true
+		}
+		if t.Sys.LinkType != ENTRY && t.Sys.LinkType != ASSET {
+			return true
+		}
+		key := t.Sys.LinkType + t.Sys.ID
+		if !seen[key] {
+			seen[key] = true
+			links = append(links, t)
+		}
+		return true
+	})
+	return links
+}
+
+// HasMark reports whether a text node has the mark
+func (n *RichTextNode) HasMark(mark string) bool {
+	for _, m := range n.Marks {
+		if m.Type == mark {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,364 @@
+package gontentful
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// safeURISchemes may be linked from hyperlinks, relative uris are allowed as well
+var safeURISchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+}
+
+// RichTextNodeRenderer renders a node, children holds its already rendered content
+type RichTextNodeRenderer func(node *RichTextNode, children string) string
+
+// RichTextRenderer renders Rich Text documents to HTML or Markdown. Embedded entries and
+// assets render as placeholders (HTML) or nothing (Markdown) unless a node renderer is set,
+// hyperlinks to uris other than http, https, mailto, tel or relative ones render as their text.
+//
+//	r := &gontentful.RichTextRenderer{
+//		NodeRenderers: map[string]gontentful.RichTextNodeRenderer{
+//			gontentful.RichTextEmbeddedAssetBlock: func(n *gontentful.RichTextNode, _ string) string {
+//				return fmt.Sprintf(`<img src="%s"/>`, assetURLs[n.Data.Target.Sys.ID])
+//			},
+//		},
+//	}
+//	body := r.HTML(doc)
+type RichTextRenderer struct {
+	// NodeRenderers override the rendering of node types
+	NodeRenderers map[string]RichTextNodeRenderer
+	// MarkRenderers override the rendering of marks, text is the rendered text node
+	MarkRenderers map[string]func(text string) string
+}
+
+// RichTextToHTML renders the document with the default renderers
+func RichTextToHTML(doc *RichTextNode) string {
+	return (&RichTextRenderer{}).HTML(doc)
+}
+
+// RichTextToMarkdown renders the document with the default renderers
+func RichTextToMarkdown(doc *RichTextNode) string {
+	return (&RichTextRenderer{}).Markdown(doc)
+}
+
+func (r *RichTextRenderer) HTML(doc *RichTextNode) string {
+	if doc == nil {
+		return ""
+	}
+	return r.html(doc)
+}
+
+func (r *RichTextRenderer) Markdown(doc *RichTextNode) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(r.markdown(doc))
+}
+
+func (r *RichTextRenderer) nodeRenderer(nodeType string) RichTextNodeRenderer {
+	if r == nil || r.NodeRenderers == nil {
+		return nil
+	}
+	return r.NodeRenderers[nodeType]
+}
+
+func (r *RichTextRenderer) markRenderer(mark string) func(string) string {
+	if r == nil || r.MarkRenderers == nil {
+		return nil
+	}
+	return r.MarkRenderers[mark]
+}
+
+func (r *RichTextRenderer) htmlContent(n *RichTextNode) string {
+	var sb strings.Builder
+	for _, c := range n.Content {
+		sb.WriteString(r.html(c))
+	}
+	return sb.String()
+}
+
+var htmlTags = map[string]string{
+	RichTextParagraph:       "p",
+	RichTextHeading1:        "h1",
+	RichTextHeading2:        "h2",
+	RichTextHeading3:        "h3",
+	RichTextHeading4:        "h4",
+	RichTextHeading5:        "h5",
+	RichTextHeading6:        "h6",
+	RichTextOrderedList:     "ol",
+	RichTextUnorderedList:   "ul",
+	RichTextListItem:        "li",
+	RichTextQuote:           "blockquote",
+	RichTextTable:           "table",
+	RichTextTableRow:        "tr",
+	RichTextTableCell:       "td",
+	RichTextTableHeaderCell: "th",
+}
+
+var htmlMarkTags = map[string]string{
+	RichTextMarkBold:          "b",
+	RichTextMarkItalic:        "i",
+	RichTextMarkUnderline:     "u",
+	RichTextMarkCode:          "code",
+	RichTextMarkSuperscript:   "sup",
+	RichTextMarkSubscript:     "sub",
+	RichTextMarkStrikethrough: "s",
+}
+
+func (r *RichTextRenderer) html(n *RichTextNode) string {
+	if n.NodeType == RichTextText {
+		return r.text(n, html.EscapeString(n.Value), func(mark string, s string) string {
+			if tag, ok := htmlMarkTags[mark]; ok {
+				return fmt.Sprintf("<%s>%s</%s>", tag, s, tag)
+			}
+			return s
+		})
+	}
+
+	children := r.htmlContent(n)
+	if fn := r.nodeRenderer(n.NodeType); fn != nil {
+		return fn(n, children)
+	}
+
+	if tag, ok := htmlTags[n.NodeType]; ok {
+		return fmt.Sprintf("<%s>%s</%s>", tag, children, tag)
+	}
+	switch n.NodeType {
+	case RichTextHR:
+		return "<hr/>"
+	case RichTextHyperlink:
+		if !safeURI(n.Data.URI) {
+			return children
+		}
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(n.Data.URI), children)
+	case RichTextEntryHyperlink:
+		return fmt.Sprintf(`<a data-entry-id="%s">%s</a>`, html.EscapeString(targetID(n)), children)
+	case RichTextAssetHyperlink:
+		return fmt.Sprintf(`<a data-asset-id="%s">%s</a>`, html.EscapeString(targetID(n)), children)
+	case RichTextEmbeddedEntryBlock:
+		return fmt.Sprintf(`<div data-entry-id="%s"></div>`, html.EscapeString(targetID(n)))
+	case RichTextEmbeddedAssetBlock:
+		return fmt.Sprintf(`<div data-asset-id="%s"></div>`, html.EscapeString(targetID(n)))
+	case RichTextEmbeddedEntry:
+		return fmt.Sprintf(`<span data-entry-id="%s"></span>`, html.EscapeString(targetID(n)))
+	}
+	// document and unknown nodes
+	return children
+}
+
+// text applies the marks to a text node, custom mark renderers take precedence
+func (r *RichTextRenderer) text(n *RichTextNode, s string, mark func(string, string) string) string {
+	if fn := r.nodeRenderer(RichTextText); fn != nil {
+		return fn(n, s)
+	}
+	if s == "" {
+		return s
+	}
+	for _, m := range n.Marks {
+		if fn := r.markRenderer(m.Type); fn != nil {
+			s = fn(s)
+		} else {
+			s = mark(m.Type, s)
+		}
+	}
+	return s
+}
+
+var markdownMarks = map[string][2]string{
+	RichTextMarkBold:          {"**", "**"},
+	RichTextMarkItalic:        {"_", "_"},
+	RichTextMarkUnderline:     {"<u>", "</u>"},
+	RichTextMarkCode:          {"`", "`"},
+	RichTextMarkSuperscript:   {"<sup>", "</sup>"},
+	RichTextMarkSubscript:     {"<sub>", "</sub>"},
+	RichTextMarkStrikethrough: {"~~", "~~"},
+}
+
+// markdownEscaper escapes text so it renders literally, html is escaped as
+// entities and markdown punctuation with a backslash, pipes are left to tables
+var markdownEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;",
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "~", `\~`, "#", `\#`,
+	"[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "!", `\!`,
+)
+
+// blockMarkerRegex matches list markers, rules and setext underlines at the beginning of a line
+var blockMarkerRegex = regexp.MustCompile(`(?m)^(\s*(?:\d+)?)([-+=.])`)
+
+func escapeMarkdown(s string) string {
+	return blockMarkerRegex.ReplaceAllString(markdownEscaper.Replace(s), `$1\$2`)
+}
+
+// markdownCode wraps text in a code span, the fence is longer than any backtick run in it
+func markdownCode(s string) string {
+	longest, run := 0, 0
+	for _, c := range s {
+		if c == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if longest > 0 || strings.HasPrefix(s, " ") || strings.HasSuffix(s, " ") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+// markdownURI puts a link destination in angle brackets, so spaces and
+// parentheses cannot end it early
+func markdownURI(uri string) string {
+	return "<" + markdownURIEscaper.Replace(uri) + ">"
+}
+
+var markdownURIEscaper = strings.NewReplacer(`\`, `\\`, "<", "%3C", ">", "%3E", "\n", "%0A", "\r", "%0D")
+
+func (r *RichTextRenderer) markdownContent(n *RichTextNode) string {
+	var sb strings.Builder
+	for _, c := range n.Content {
+		sb.WriteString(r.markdown(c))
+	}
+	return sb.String()
+}
+
+// markdown renders blocks followed by a blank line, inline nodes as is
+func (r *RichTextRenderer) markdown(n *RichTextNode) string {
+	if n.NodeType == RichTextText {
+		value, code := escapeMarkdown(n.Value), false
+		for _, m := range n.Marks {
+			if m.Type == RichTextMarkCode && r.markRenderer(m.Type) == nil {
+				// code spans render their content literally
+				value, code = n.Value, true
+			}
+		}
+		return r.text(n, value, func(mark string, s string) string {
+			if mark == RichTextMarkCode && code {
+				return markdownCode(s)
+			}
+			if m, ok := markdownMarks[mark]; ok {
+				return m[0] + s + m[1]
+			}
+			return s
+		})
+	}
+
+	if fn := r.nodeRenderer(n.NodeType); fn != nil {
+		return fn(n, r.markdownContent(n))
+	}
+
+	switch n.NodeType {
+	case RichTextParagraph:
+		return r.markdownContent(n) + "\n\n"
+	case RichTextHeading1, RichTextHeading2, RichTextHeading3, RichTextHeading4, RichTextHeading5, RichTextHeading6:
+		level, _ := strconv.Atoi(strings.TrimPrefix(n.NodeType, "heading-"))
+		return strings.Repeat("#", level) + " " + r.markdownContent(n) + "\n\n"
+	case RichTextHR:
+		return "---\n\n"
+	case RichTextQuote:
+		return prefixLines(strings.TrimSpace(r.markdownContent(n)), "> ", "> ") + "\n\n"
+	case RichTextOrderedList, RichTextUnorderedList:
+		return r.markdownList(n) + "\n"
+	case RichTextListItem:
+		return strings.TrimSpace(r.markdownContent(n)) + "\n"
+	case RichTextTable:
+		return r.markdownTable(n) + "\n"
+	case RichTextTableRow, RichTextTableCell, RichTextTableHeaderCell:
+		return r.markdownContent(n)
+	case RichTextHyperlink:
+		if !safeURI(n.Data.URI) {
+			return r.markdownContent(n)
+		}
+		return fmt.Sprintf("[%s](%s)", r.markdownContent(n), markdownURI(n.Data.URI))
+	case RichTextEmbeddedEntryBlock, RichTextEmbeddedAssetBlock, RichTextEmbeddedEntry:
+		return ""
+	}
+	// document, entry and asset hyperlinks and unknown nodes
+	return r.markdownContent(n)
+}
+
+func (r *RichTextRenderer) markdownList(n *RichTextNode) string {
+	var sb strings.Builder
+	for i, item := range n.Content {
+		marker := "- "
+		if n.NodeType == RichTextOrderedList {
+			marker = fmt.Sprintf("%d. ", i+1)
+		}
+		// blocks of an item are kept together, nested lists are indented under the marker
+		blocks := make([]string, 0)
+		if fn := r.nodeRenderer(item.NodeType); fn != nil {
+			blocks = append(blocks, strings.TrimSpace(fn(item, r.markdownContent(item))))
+		} else {
+			for _, c := range item.Content {
+				if b := strings.TrimRight(r.markdown(c), "\n"); b != "" {
+					blocks = append(blocks, b)
+				}
+			}
+		}
+		sb.WriteString(prefixLines(strings.Join(blocks, "\n"), marker, strings.Repeat(" ", len(marker))))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func (r *RichTextRenderer) markdownTable(n *RichTextNode) string {
+	var sb strings.Builder
+	for i, row := range n.Content {
+		cells := make([]string, 0, len(row.Content))
+		for _, cell := range row.Content {
+			s := strings.TrimSpace(r.markdown(cell))
+			s = strings.ReplaceAll(s, "|", "\\|")
+			s = strings.ReplaceAll(s, "\n\n", "<br>")
+			cells = append(cells, strings.ReplaceAll(s, "\n", " "))
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			sb.WriteString(strings.Repeat("| --- ", len(cells)) + "|\n")
+		}
+	}
+	return sb.String()
+}
+
+func prefixLines(s string, first string, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		p := rest
+		if i == 0 {
+			p = first
+		}
+		if l == "" && i > 0 {
+			lines[i] = strings.TrimRight(p, " ")
+			continue
+		}
+		lines[i] = p + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+func targetID(n *RichTextNode) string {
+	if n.Data.Target != nil && n.Data.Target.Sys != nil {
+		return n.Data.Target.Sys.ID
+	}
+	return ""
+}
+
+// safeURI reports whether a hyperlink uri is relative or uses a safe scheme,
+// so javascript:, data: and vbscript: links render as plain text
+func safeURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || safeURISchemes[strings.ToLower(u.Scheme)]
+}
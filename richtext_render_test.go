@@ -0,0 +1,113 @@
+package gontentful
+
+import (
+	"strings"
+	"testing"
+)
+
+func hyperlinkDoc(uri string) *RichTextNode {
+	return &RichTextNode{
+		NodeType: RichTextDocument,
+		Content: []*RichTextNode{{
+			NodeType: RichTextParagraph,
+			Content: []*RichTextNode{{
+				NodeType: RichTextHyperlink,
+				Data:     RichTextData{URI: uri},
+				Content:  []*RichTextNode{{NodeType: RichTextText, Value: "click"}},
+			}},
+		}},
+	}
+}
+
+func TestRichTextHyperlinkSchemes(t *testing.T) {
+	safe := []string{
+		"https://example.com/a?b=c",
+		"http://example.com",
+		"mailto:support@example.com",
+		"tel:+3612345678",
+		"/games/slots",
+		"#terms",
+	}
+	for _, uri := range safe {
+		if got := RichTextToHTML(hyperlinkDoc(uri)); !strings.Contains(got, "<a href=") {
+			t.Errorf("%s: got %s, want a link", uri, got)
+		}
+		if got := RichTextToMarkdown(hyperlinkDoc(uri)); !strings.Contains(got, "[click](<"+uri+">)") {
+			t.Errorf("%s: got markdown %q, want a link", uri, got)
+		}
+	}
+
+	unsafe := []string{
+		"javascript:alert(1)",
+		"JavaScript:alert(1)",
+		" javascript:alert(1)",
+		"java\tscript:alert(1)",
+		"data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==",
+		"vbscript:msgbox(1)",
+	}
+	for _, uri := range unsafe {
+		if got := RichTextToHTML(hyperlinkDoc(uri)); got != "<p>click</p>" {
+			t.Errorf("%q: got %s, want plain text", uri, got)
+		}
+		if got := RichTextToMarkdown(hyperlinkDoc(uri)); strings.Contains(got, "](") {
+			t.Errorf("%q: got markdown %q, want plain text", uri, got)
+		}
+	}
+}
+
+func textDoc(nodes ...*RichTextNode) *RichTextNode {
+	return &RichTextNode{
+		NodeType: RichTextDocument,
+		Content:  []*RichTextNode{{NodeType: RichTextParagraph, Content: nodes}},
+	}
+}
+
+func TestRichTextMarkdownEscapesText(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want string
+	}{
+		{"2 * 3 = 6", `2 \* 3 = 6`},
+		{"snake_case and ~strike~", `snake\_case and \~strike\~`},
+		{"[not](a link)", `\[not\]\(a link\)`},
+		{"# not a heading", `\# not a heading`},
+		{"- not a list", `\- not a list`},
+		{"1. not a list", `1\. not a list`},
+		{"<script>alert(1)</script>", `&lt;script&gt;alert\(1\)&lt;/script&gt;`},
+		{"AT&T", "AT&amp;T"},
+		{"back\\slash `tick`", "back\\\\slash \\`tick\\`"},
+	} {
+		if got := RichTextToMarkdown(textDoc(&RichTextNode{NodeType: RichTextText, Value: tc.text})); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestRichTextMarkdownMarks(t *testing.T) {
+	got := RichTextToMarkdown(textDoc(
+		&RichTextNode{NodeType: RichTextText, Value: "a*b", Marks: []*RichTextMark{{Type: RichTextMarkBold}}},
+		&RichTextNode{NodeType: RichTextText, Value: " "},
+		&RichTextNode{NodeType: RichTextText, Value: "<b>*x*</b>", Marks: []*RichTextMark{{Type: RichTextMarkCode}}},
+		&RichTextNode{NodeType: RichTextText, Value: " "},
+		&RichTextNode{NodeType: RichTextText, Value: "a`b", Marks: []*RichTextMark{{Type: RichTextMarkCode}}},
+	))
+	if want := "**a\\*b** `<b>*x*</b>` `` a`b ``"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRichTextMarkdownLinkURI(t *testing.T) {
+	for _, tc := range []struct {
+		uri  string
+		want string
+	}{
+		{"https://example.com/a_(b)", "[click](<https://example.com/a_(b)>)"},
+		{"https://example.com/a b", "[click](<https://example.com/a b>)"},
+		{"https://example.com/<x>", "[click](<https://example.com/%3Cx%3E>)"},
+		{"https://example.com/a) [x](javascript:y", "[click](<https://example.com/a) [x](javascript:y>)"},
+	} {
+		if got := RichTextToMarkdown(hyperlinkDoc(tc.uri)); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.uri, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	SCHEDULED_ACTION = "ScheduledAction"
+
+	ScheduledActionPublish   = "publish"
+	ScheduledActionUnpublish = "unpublish"
+
+	ScheduledActionStatusScheduled  = "scheduled"
+	ScheduledActionStatusInProgress = "inProgress"
+	ScheduledActionStatusSucceeded  = "succeeded"
+	ScheduledActionStatusFailed     = "failed"
+	ScheduledActionStatusCanceled   = "canceled"
+
+	queryEnvironmentID = "environment.sys.id"
+)
+
+type ScheduledActionSys struct {
+	Sys
+	Status string `json:"status,omitempty"`
+}
+
+type ScheduledFor struct {
+	Datetime string `json:"datetime"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type ScheduledAction struct {
+	Sys          *ScheduledActionSys `json:"sys,omitempty"`
+	Entity       *Entry              `json:"entity"`
+	Environment  *Entry              `json:"environment"`
+	ScheduledFor *ScheduledFor       `json:"scheduledFor"`
+	Action       string              `json:"action"`
+	Error        *ActionError        `json:"error,omitempty"`
+}
+
+type ScheduledActions struct {
+	Sys   *Sys               `json:"sys"`
+	Limit int                `json:"limit"`
+	Items []*ScheduledAction `json:"items"`
+}
+
+// Status returns scheduled, inProgress, succeeded, failed or canceled
+func (a *ScheduledAction) Status() string {
+	if a.Sys == nil {
+		return ""
+	}
+	return a.Sys.Status
+}
+
+// ScheduledActionsService schedules publishing and unpublishing of entries, assets and releases
+// in the environment of the client
+type ScheduledActionsService service
+
+// Schedule schedules the action on the linked entity, a release is linked with the Release link type.
+// The time is sent in UTC, the location of at only matters for the instant it denotes
+func (s *ScheduledActionsService) Schedule(action string, entity Link, at time.Time) (*ScheduledAction, error) {
+	return s.ScheduleContext(context.Background(), action, entity, at)
+}
+
+func (s *ScheduledActionsService) ScheduleContext(ctx context.Context, action string, entity Link, at time.Time) (*ScheduledAction, error) {
+	body, err := json.Marshal(&ScheduledAction{
+		Entity:       entity.entry(),
+		Environment:  Link{LinkType: ENVIRONMENT, ID: s.client.Options.EnvironmentID}.entry(),
+		ScheduledFor: &ScheduledFor{Datetime: at.UTC().Format(time.RFC3339)},
+		Action:       action,
+	})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathScheduledActions, s.client.Options.SpaceID)
+	data, err := s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalScheduledAction(data)
+}
+
+// GetScheduledActions lists the scheduled actions of the environment, e.g. filtered by entity.sys.id or sys.status[in]
+func (s *ScheduledActionsService) GetScheduledActions(query url.Values) (*ScheduledActions, error) {
+	return s.GetScheduledActionsContext(context.Background(), query)
+}
+
+func (s *ScheduledActionsService) GetScheduledActionsContext(ctx context.Context, query url.Values) (*ScheduledActions, error) {
+	path := fmt.Sprintf(pathScheduledActions, s.client.Options.SpaceID)
+	data, err := s.client.getCMA(ctx, path, s.environmentQuery(query))
+	if err != nil {
+		return nil, err
+	}
+	res := &ScheduledActions{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *ScheduledActionsService) GetSingle(scheduledActionId string) (*ScheduledAction, error) {
+	return s.GetSingleContext(context.Background(), scheduledActionId)
+}
+
+func (s *ScheduledActionsService) GetSingleContext(ctx context.Context, scheduledActionId string) (*ScheduledAction, error) {
+	path := fmt.Sprintf(pathScheduledAction, s.client.Options.SpaceID, scheduledActionId)
+	data, err := s.client.getCMA(ctx, path, s.environmentQuery(nil))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalScheduledAction(data)
+}
+
+// Cancel cancels a scheduled action, it is kept with the canceled status
+func (s *ScheduledActionsService) Cancel(scheduledActionId string) (*ScheduledAction, error) {
+	return s.CancelContext(context.Background(), scheduledActionId)
+}
+
+func (s *ScheduledActionsService) CancelContext(ctx context.Context, scheduledActionId string) (*ScheduledAction, error) {
+	path := fmt.Sprintf(pathScheduledAction, s.client.Options.SpaceID, scheduledActionId)
+	data, err := s.client.deleteQuery(ctx, path, s.environmentQuery(nil))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalScheduledAction(data)
+}
+
+// environmentQuery adds the environment of the client, the api requires it on every read
+func (s *ScheduledActionsService) environmentQuery(query url.Values) url.Values {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	if q.Get(queryEnvironmentID) == "" {
+		q.Set(queryEnvironmentID, s.client.Options.EnvironmentID)
+	}
+	return q
+}
+
+func unmarshalScheduledAction(data []byte) (*ScheduledAction, error) {
+	res := &ScheduledAction{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
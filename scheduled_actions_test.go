@@ -0,0 +1,30 @@
+package gontentful_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestScheduleSendsUTC(t *testing.T) {
+	var action gontentful.ScheduledAction
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&action)
+		w.Write([]byte(`{"sys":{"id":"a","type":"ScheduledAction","status":"scheduled"}}`))
+	}))
+	defer srv.Close()
+
+	client := gontentful.NewClient(&gontentful.ClientOptions{SpaceID: "space", EnvironmentID: "master", CmaURL: srv.URL, CmaToken: "token"})
+	at := time.Date(2026, 6, 1, 9, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	_, err := client.ScheduledActions.Schedule(gontentful.ScheduledActionPublish, gontentful.Link{LinkType: gontentful.ENTRY, ID: "a"}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ScheduledFor == nil || action.ScheduledFor.Datetime != "2026-06-01T07:00:00Z" || action.ScheduledFor.Timezone != "" {
+		t.Errorf("got scheduledFor %+v", action.ScheduledFor)
+	}
+}
@@ -295,7 +295,7 @@ func getFieldType(schema *GraphQLSchema, field *ContentTypeField) string {
 		return getArrayType(schema, field)
 	case "Link":
 		return getLinkType(schema, field)
-	case "Object":
+	case "Object", RICH_TEXT:
 		return "Map" // scalar Map
 	default:
 		return "String"
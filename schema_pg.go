@@ -104,9 +104,23 @@ type PGSQLDependency struct {
 	Reference string
 }
 
+// PGLocaleAliases maps locale codes to extra codes, not known to Contentful, that are
+// served by views over the materialized views of the locale
+type PGLocaleAliases map[string][]string
+
+// Add adds the aliases of a locale, skipping those it already has
+func (a PGLocaleAliases) Add(code string, aliases ...string) {
+	for _, alias := range aliases {
+		if !containsString(a[code], alias) {
+			a[code] = append(a[code], alias)
+		}
+	}
+}
+
 type PGSQLSchema struct {
 	SchemaName         string
 	Locales            []*Locale
+	LocaleAliases      PGLocaleAliases
 	Tables             []*PGSQLTable
 	ConTables          []*PGSQLTable
 	References         []*PGSQLReference
@@ -136,6 +150,7 @@ func NewPGSQLSchema(schemaName string, locales []*Locale, contentTypeFilter stri
 	schema := &PGSQLSchema{
 		SchemaName:      schemaName,
 		Locales:         locales,
+		LocaleAliases:   make(PGLocaleAliases),
 		Tables:          make([]*PGSQLTable, 0),
 		ConTables:       make([]*PGSQLTable, 0),
 		References:      make([]*PGSQLReference, 0),
@@ -146,6 +161,12 @@ func NewPGSQLSchema(schemaName string, locales []*Locale, contentTypeFilter stri
 		AssetTable:      NewPGSQLAssetTable(),
 	}
 
+	for _, l := range locales {
+		if len(l.CFLocales) > 0 {
+			schema.LocaleAliases.Add(l.Code, l.CFLocales...)
+		}
+	}
+
 	itemsMap := make(map[string]*ContentType)
 	for _, item := range items {
 		itemsMap[item.Sys.ID] = item
@@ -266,6 +287,8 @@ func NewPGSQLTable(item *ContentType, items map[string]*ContentType, includeDept
 				references, dependencies = addOneTOne(references, dependencies, table.TableName, field)
 			} else if field.Items != nil {
 				conTables, references, dependencies = addManyToMany(conTables, references, dependencies, table.TableName, field)
+			} else if field.Type == RICH_TEXT {
+				conTables, references = addRichTextLinks(conTables, references, table.TableName, field)
 			}
 			proc.Columns = append(proc.Columns, procColumn)
 			if procColumn.Localized {
@@ -323,7 +346,7 @@ func getColumnType(fieldType string, fieldItems *FieldTypeArrayItem) string {
 			return fmt.Sprintf("%s ARRAY", getColumnType(fieldItems.Type, nil))
 		}
 		return "text ARRAY"
-	case "Object":
+	case "Object", RICH_TEXT:
 		return "jsonb"
 	default:
 		return "text"
@@ -369,6 +392,22 @@ func NewPGSQLCon(tableName string, fieldName string, reference string) *PGSQLTab
 	}
 }
 
+func NewPGSQLRichTextCon(tableName string, fieldName string) *PGSQLTable {
+	columns := make([]*PGSQLColumn, 0)
+	for _, c := range getRichTextConColumns(tableName) {
+		columns = append(columns, &PGSQLColumn{ColumnName: c})
+	}
+	return &PGSQLTable{
+		TableName: getConTableName(tableName, fieldName),
+		Columns:   columns,
+		Indices:   map[string]string{"id_locale": fmt.Sprintf("%s_sys_id,_locale", tableName), "link_sys_id_locale": "_link_sys_id,_locale"},
+	}
+}
+
+func getRichTextConColumns(tableName string) []string {
+	return []string{tableName, fmt.Sprintf("%s_sys_id", tableName), "_link_type", "_link_sys_id", "_locale"}
+}
+
 func getConTableName(tableName string, fieldName string) string {
 	return fmt.Sprintf("%.63s", fmt.Sprintf("c_%s__%s", tableName, fieldName))
 }
@@ -435,6 +474,20 @@ func addManyToMany(conTables []*PGSQLTable, references []*PGSQLReference, depend
 	return conTables, references, dependencies
 }
 
+// addRichTextLinks adds a con table for the entries and assets embedded in a rich text field,
+// the links can target any content type so only the owner side has a foreign key
+func addRichTextLinks(conTables []*PGSQLTable, references []*PGSQLReference, tableName string, field *ContentTypeField) ([]*PGSQLTable, []*PGSQLReference) {
+	conTable := NewPGSQLRichTextCon(tableName, toSnakeCase(field.ID))
+	conTables = append(conTables, conTable)
+	references = append(references, &PGSQLReference{
+		TableName:    conTable.TableName,
+		Reference:    tableName,
+		ForeignKey:   tableName,
+		IsManyToMany: true,
+	})
+	return conTables, references
+}
+
 func NewPGSQLProcedureColumn(columnName string, field *ContentTypeField, items map[string]*ContentType, tableName string, maxIncludeDepth int64, includeDepth int64, path string) *PGSQLProcedureColumn {
 	col := &PGSQLProcedureColumn{
 		TableName:  tableName,
@@ -554,7 +607,7 @@ func mapFieldType(fieldName string, fieldType string, fieldItems *FieldTypeArray
 		return "date"
 	case "Location":
 		return "point"
-	case "Object":
+	case "Object", RICH_TEXT:
 		return "jsonb"
 	case "Symbol":
 		return "text"
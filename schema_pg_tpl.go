@@ -22,9 +22,12 @@ CREATE TABLE IF NOT EXISTS {{ $.AssetTable.Name }} (
 	_updated_at timestamp without time zone default now(),
 	_updated_by text not null,
 	_published_at timestamp without time zone,
-	_published_by text
+	_published_by text,
+	_tags text[] not null default '{}'
 );
+ALTER TABLE {{ $.AssetTable.Name }} ADD COLUMN IF NOT EXISTS _tags text[] not null default '{}';
 CREATE UNIQUE INDEX IF NOT EXISTS {{ $.AssetTable.Name }}__sys_id__locale ON {{ $.AssetTable.Name }} (_sys_id, _locale);
+CREATE INDEX IF NOT EXISTS {{ $.AssetTable.Name }}__tags ON {{ $.AssetTable.Name }} USING GIN (_tags);
 --
 CREATE TABLE IF NOT EXISTS {{ $.SchemaTableName }} (
 	table_name text primary key,
@@ -82,10 +85,13 @@ CREATE TABLE IF NOT EXISTS {{ $tbl.TableName }} (
 	_updated_at timestamp without time zone not null default now(),
 	_updated_by text not null,
 	_published_at timestamp without time zone,
-	_published_by text
+	_published_by text,
+	_tags text[] not null default '{}'
 );
+ALTER TABLE {{ $tbl.TableName }} ADD COLUMN IF NOT EXISTS _tags text[] not null default '{}';
 --
 CREATE UNIQUE INDEX IF NOT EXISTS idx_{{ $tbl.TableName }}__sys_id_locale ON {{ $tbl.TableName }}(_sys_id,_locale);
+CREATE INDEX IF NOT EXISTS idx_{{ $tbl.TableName }}__tags ON {{ $tbl.TableName }} USING GIN (_tags);
 CREATE INDEX IF NOT EXISTS idx_{{ $tbl.TableName }}__sys_id ON {{ $tbl.TableName }}(_sys_id);
 CREATE INDEX IF NOT EXISTS idx_{{ $tbl.TableName }}__locale ON {{ $tbl.TableName }}(_locale);
 {{- range $tbl.Columns -}}
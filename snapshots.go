@@ -0,0 +1,159 @@
+package gontentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+const (
+	SNAPSHOT = "Snapshot"
+
+	SnapshotTypePublish = "publish"
+)
+
+type SnapshotSys struct {
+	Sys
+	SnapshotType       string `json:"snapshotType,omitempty"`
+	SnapshotEntityType string `json:"snapshotEntityType,omitempty"`
+}
+
+// Snapshot is an entry as it was published, in the management api format
+type Snapshot struct {
+	Sys      *SnapshotSys    `json:"sys"`
+	Snapshot *PublishedEntry `json:"snapshot"`
+}
+
+type Snapshots struct {
+	Sys   *Sys        `json:"sys"`
+	Total int         `json:"total"`
+	Skip  int         `json:"skip"`
+	Limit int         `json:"limit"`
+	Items []*Snapshot `json:"items"`
+}
+
+type ContentTypeSnapshot struct {
+	Sys      *SnapshotSys `json:"sys"`
+	Snapshot *ContentType `json:"snapshot"`
+}
+
+type ContentTypeSnapshots struct {
+	Sys   *Sys                   `json:"sys"`
+	Total int                    `json:"total"`
+	Skip  int                    `json:"skip"`
+	Limit int                    `json:"limit"`
+	Items []*ContentTypeSnapshot `json:"items"`
+}
+
+func (s *EntriesService) GetSnapshots(entryId string, query url.Values) (*Snapshots, error) {
+	return s.GetSnapshotsContext(context.Background(), entryId, query)
+}
+
+func (s *EntriesService) GetSnapshotsContext(ctx context.Context, entryId string, query url.Values) (*Snapshots, error) {
+	path := fmt.Sprintf(pathEntrySnapshots, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	res := &Snapshots{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *EntriesService) GetSnapshot(entryId string, snapshotId string) (*Snapshot, error) {
+	return s.GetSnapshotContext(context.Background(), entryId, snapshotId)
+}
+
+func (s *EntriesService) GetSnapshotContext(ctx context.Context, entryId string, snapshotId string) (*Snapshot, error) {
+	path := fmt.Sprintf(pathEntrySnapshot, s.client.Options.SpaceID, s.client.Options.EnvironmentID, entryId, snapshotId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &Snapshot{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// History fetches every snapshot of the entry and diffs each one with the previous, oldest first
+func (s *EntriesService) History(entryId string) ([]*SnapshotDiff, error) {
+	return s.HistoryContext(context.Background(), entryId)
+}
+
+func (s *EntriesService) HistoryContext(ctx context.Context, entryId string) ([]*SnapshotDiff, error) {
+	items := make([]*Snapshot, 0)
+	fetch := func(ctx context.Context, query url.Values) (*Snapshots, error) {
+		return s.GetSnapshotsContext(ctx, entryId, query)
+	}
+	err := paginate(ctx, url.Values{}, nil, fetch, func(p *Snapshots) int { return p.Total }, func(p *Snapshots) error {
+		items = append(items, p.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Sys.CreatedAt != items[j].Sys.CreatedAt {
+			return items[i].Sys.CreatedAt < items[j].Sys.CreatedAt
+		}
+		return snapshotVersion(items[i]) < snapshotVersion(items[j])
+	})
+	res := make([]*SnapshotDiff, 0, len(items))
+	var prev *Snapshot
+	for _, item := range items {
+		res = append(res, DiffSnapshots(prev, item))
+		prev = item
+	}
+	return res, nil
+}
+
+func (s *ContentTypesService) GetSnapshots(contentTypeId string, query url.Values) (*ContentTypeSnapshots, error) {
+	return s.GetSnapshotsContext(context.Background(), contentTypeId, query)
+}
+
+func (s *ContentTypesService) GetSnapshotsContext(ctx context.Context, contentTypeId string, query url.Values) (*ContentTypeSnapshots, error) {
+	path := fmt.Sprintf(pathContentTypeSnapshots, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentTypeId)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	res := &ContentTypeSnapshots{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *ContentTypesService) GetSnapshot(contentTypeId string, snapshotId string) (*ContentTypeSnapshot, error) {
+	return s.GetSnapshotContext(context.Background(), contentTypeId, snapshotId)
+}
+
+func (s *ContentTypesService) GetSnapshotContext(ctx context.Context, contentTypeId string, snapshotId string) (*ContentTypeSnapshot, error) {
+	path := fmt.Sprintf(pathContentTypeSnapshot, s.client.Options.SpaceID, s.client.Options.EnvironmentID, contentTypeId, snapshotId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &ContentTypeSnapshot{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func snapshotVersion(s *Snapshot) int {
+	if s.Snapshot == nil || s.Snapshot.Sys == nil {
+		return 0
+	}
+	return s.Snapshot.Sys.Version
+}
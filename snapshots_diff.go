@@ -0,0 +1,195 @@
+package gontentful
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+
+	metadataTagsField = "metadata.tags"
+)
+
+// FieldChange is a change of a field value in one locale. Content type changes have no locale,
+// their field definitions are named fields.<id>
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Locale string      `json:"locale,omitempty"`
+	Kind   string      `json:"kind"`
+	From   interface{} `json:"from,omitempty"`
+	To     interface{} `json:"to,omitempty"`
+}
+
+// SnapshotDiff holds the changes between two snapshots, To.CreatedBy is who made them
+type SnapshotDiff struct {
+	From    *SnapshotSys   `json:"from,omitempty"`
+	To      *SnapshotSys   `json:"to"`
+	Changes []*FieldChange `json:"changes"`
+}
+
+// Changed reports whether the field was changed in any locale
+func (d *SnapshotDiff) Changed(field string) bool {
+	for _, c := range d.Changes {
+		if c.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffSnapshots compares two entry snapshots, a nil from diffs against an empty entry
+func DiffSnapshots(from *Snapshot, to *Snapshot) *SnapshotDiff {
+	res := &SnapshotDiff{}
+	var fromEntry, toEntry *PublishedEntry
+	if from != nil {
+		res.From = from.Sys
+		fromEntry = from.Snapshot
+	}
+	if to != nil {
+		res.To = to.Sys
+		toEntry = to.Snapshot
+	}
+	res.Changes = DiffEntries(fromEntry, toEntry)
+	return res
+}
+
+// DiffEntries compares the fields of two entries in the management api format per field and locale,
+// tag changes are reported on the metadata.tags field
+func DiffEntries(from *PublishedEntry, to *PublishedEntry) []*FieldChange {
+	fromFields, toFields := PublishFields{}, PublishFields{}
+	var fromMeta, toMeta *Metadata
+	if from != nil {
+		fromFields, fromMeta = from.Fields, from.Metadata
+	}
+	if to != nil {
+		toFields, toMeta = to.Fields, to.Metadata
+	}
+
+	changes := make([]*FieldChange, 0)
+	for _, field := range unionKeys(fromFields, toFields) {
+		fromLocs, toLocs := fromFields[field], toFields[field]
+		for _, loc := range unionKeys(fromLocs, toLocs) {
+			fv, fok := fromLocs[loc]
+			tv, tok := toLocs[loc]
+			if c := diffValue(field, loc, fv, fok, tv, tok); c != nil {
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	fromTags, toTags := fromMeta.TagIDs(), toMeta.TagIDs()
+	sort.Strings(fromTags)
+	sort.Strings(toTags)
+	if c := diffValue(metadataTagsField, "", fromTags, len(fromTags) > 0, toTags, len(toTags) > 0); c != nil {
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// DiffContentTypeSnapshots compares two content type snapshots, a nil from diffs against an empty content type
+func DiffContentTypeSnapshots(from *ContentTypeSnapshot, to *ContentTypeSnapshot) *SnapshotDiff {
+	res := &SnapshotDiff{}
+	var fromType, toType *ContentType
+	if from != nil {
+		res.From = from.Sys
+		fromType = from.Snapshot
+	}
+	if to != nil {
+		res.To = to.Sys
+		toType = to.Snapshot
+	}
+	res.Changes = DiffContentTypes(fromType, toType)
+	return res
+}
+
+// DiffContentTypes compares the name, description, display field and field definitions of two content types
+func DiffContentTypes(from *ContentType, to *ContentType) []*FieldChange {
+	if from == nil {
+		from = &ContentType{}
+	}
+	if to == nil {
+		to = &ContentType{}
+	}
+
+	changes := make([]*FieldChange, 0)
+	props := []struct {
+		name     string
+		from, to string
+	}{
+		{"name", from.Name, to.Name},
+		{"description", from.Description, to.Description},
+		{"displayField", from.DisplayField, to.DisplayField},
+	}
+	for _, p := range props {
+		if c := diffValue(p.name, "", p.from, p.from != "", p.to, p.to != ""); c != nil {
+			changes = append(changes, c)
+		}
+	}
+
+	fromFields, toFields := contentTypeFields(from), contentTypeFields(to)
+	for _, id := range unionKeys(fromFields, toFields) {
+		fv, fok := fromFields[id]
+		tv, tok := toFields[id]
+		if c := diffValue("fields."+id, "", fv, fok, tv, tok); c != nil {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func contentTypeFields(ct *ContentType) map[string]*ContentTypeField {
+	res := make(map[string]*ContentTypeField)
+	for _, f := range ct.Fields {
+		res[f.ID] = f
+	}
+	return res
+}
+
+func diffValue(field string, locale string, from interface{}, fromOk bool, to interface{}, toOk bool) *FieldChange {
+	switch {
+	case !fromOk && !toOk:
+		return nil
+	case !fromOk:
+		return &FieldChange{Field: field, Locale: locale, Kind: ChangeAdded, To: to}
+	case !toOk:
+		return &FieldChange{Field: field, Locale: locale, Kind: ChangeRemoved, From: from}
+	case equalValues(from, to):
+		return nil
+	}
+	return &FieldChange{Field: field, Locale: locale, Kind: ChangeModified, From: from, To: to}
+}
+
+// equalValues compares values by their json form, so decoded and typed values compare equal
+func equalValues(a interface{}, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ja) == string(jb)
+}
+
+func unionKeys[V any](a map[string]V, b map[string]V) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,181 @@
+package gontentful_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func changeKinds(changes []*gontentful.FieldChange) map[string]string {
+	res := make(map[string]string)
+	for _, c := range changes {
+		key := c.Field
+		if c.Locale != "" {
+			key += "/" + c.Locale
+		}
+		res[key] = c.Kind
+	}
+	return res
+}
+
+func TestDiffEntries(t *testing.T) {
+	from := &gontentful.PublishedEntry{
+		Fields: gontentful.PublishFields{
+			"name":     {"en": "Starburst", "de": "Starburst"},
+			"priority": {"en": 1},
+			"logo":     {"en": link("Asset", "a")},
+		},
+		Metadata: gontentful.NewMetadata("b", "a"),
+	}
+	to := &gontentful.PublishedEntry{
+		Fields: gontentful.PublishFields{
+			"name":     {"en": "Starburst XXXtreme"},
+			"priority": {"en": float64(1)},
+			"logo":     {"en": link("Asset", "b")},
+			"slug":     {"en": "starburst"},
+		},
+		Metadata: gontentful.NewMetadata("a", "b"),
+	}
+
+	want := map[string]string{
+		"name/en": gontentful.ChangeModified,
+		"name/de": gontentful.ChangeRemoved,
+		"logo/en": gontentful.ChangeModified,
+		"slug/en": gontentful.ChangeAdded,
+	}
+	if got := changeKinds(gontentful.DiffEntries(from, to)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// a nil entry is empty, tags are compared as sets
+	to.Metadata = gontentful.NewMetadata("a")
+	want = map[string]string{
+		"name/en":       gontentful.ChangeAdded,
+		"priority/en":   gontentful.ChangeAdded,
+		"logo/en":       gontentful.ChangeAdded,
+		"slug/en":       gontentful.ChangeAdded,
+		"metadata.tags": gontentful.ChangeAdded,
+	}
+	if got := changeKinds(gontentful.DiffEntries(nil, to)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := gontentful.DiffEntries(from, to); changeKinds(got)["metadata.tags"] != gontentful.ChangeModified {
+		t.Errorf("got %v, want a tag change", changeKinds(got))
+	}
+	if got := gontentful.DiffEntries(to, to); len(got) != 0 {
+		t.Errorf("got %v for the same entry", changeKinds(got))
+	}
+}
+
+func TestDiffContentTypes(t *testing.T) {
+	from := &gontentful.ContentType{
+		Name:         "Game",
+		DisplayField: "name",
+		Fields: []*gontentful.ContentTypeField{
+			{ID: "name", Name: "Name", Type: "Symbol"},
+			{ID: "priority", Name: "Priority", Type: "Integer"},
+			{ID: "logo", Name: "Logo", Type: "Link", LinkType: "Asset"},
+		},
+	}
+	to := &gontentful.ContentType{
+		Name:        "Game",
+		Description: "A casino game",
+		Fields: []*gontentful.ContentTypeField{
+			{ID: "name", Name: "Name", Type: "Symbol", Localized: true},
+			{ID: "logo", Name: "Logo", Type: "Link", LinkType: "Asset"},
+			{ID: "slug", Name: "Slug", Type: "Symbol"},
+		},
+	}
+
+	want := map[string]string{
+		"description":     gontentful.ChangeAdded,
+		"displayField":    gontentful.ChangeRemoved,
+		"fields.name":     gontentful.ChangeModified,
+		"fields.priority": gontentful.ChangeRemoved,
+		"fields.slug":     gontentful.ChangeAdded,
+	}
+	if got := changeKinds(gontentful.DiffContentTypes(from, to)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := gontentful.DiffContentTypes(to, to); len(got) != 0 {
+		t.Errorf("got %v for the same content type", changeKinds(got))
+	}
+}
+
+func TestEntryHistory(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("game1", "game", gontentful.Fields{
+		"name":     localized("Starburst"),
+		"priority": localized(1),
+	}))
+
+	_, err := client.Entries.Modify("game1", func(e *gontentful.Entry) error {
+		e.Fields["name"] = localized("Starburst XXXtreme")
+		delete(e.Fields, "priority")
+		e.Fields["slug"] = localized("starburst")
+		return nil
+	}, &gontentful.ModifyOptions{Publish: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := client.Entries.History("game1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d diffs, want 2", len(history))
+	}
+	if history[0].From != nil || history[0].To == nil {
+		t.Errorf("the first diff should start from an empty entry, got %+v", history[0])
+	}
+	want := map[string]string{
+		"name/" + gontentful.DefaultLocale:     gontentful.ChangeAdded,
+		"priority/" + gontentful.DefaultLocale: gontentful.ChangeAdded,
+	}
+	if got := changeKinds(history[0].Changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("first publish: got %v, want %v", got, want)
+	}
+	want = map[string]string{
+		"name/" + gontentful.DefaultLocale:     gontentful.ChangeModified,
+		"priority/" + gontentful.DefaultLocale: gontentful.ChangeRemoved,
+		"slug/" + gontentful.DefaultLocale:     gontentful.ChangeAdded,
+	}
+	if got := changeKinds(history[1].Changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("second publish: got %v, want %v", got, want)
+	}
+	if !history[1].Changed("slug") || history[1].Changed("logo") {
+		t.Error("Changed does not match the changes")
+	}
+}
+
+func TestContentTypeSnapshotDiff(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddContentType(&gontentful.ContentType{
+		Sys:    &gontentful.Sys{ID: "game"},
+		Name:   "Game",
+		Fields: []*gontentful.ContentTypeField{{ID: "name", Name: "Name", Type: "Symbol"}},
+	})
+	_, err := client.ContentTypes.Modify("game", func(ct *gontentful.ContentType) error {
+		ct.Fields = append(ct.Fields, &gontentful.ContentTypeField{ID: "slug", Name: "Slug", Type: "Symbol"})
+		return nil
+	}, &gontentful.ModifyOptions{Publish: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := client.ContentTypes.GetSnapshots("game", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots.Items) != 2 {
+		t.Fatalf("got %d snapshots, want 2", len(snapshots.Items))
+	}
+	// snapshots are listed newest first
+	diff := gontentful.DiffContentTypeSnapshots(snapshots.Items[1], snapshots.Items[0])
+	want := map[string]string{"fields.slug": gontentful.ChangeAdded}
+	if got := changeKinds(diff.Changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package gontentful
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -10,12 +11,20 @@ import (
 type SpacesService service
 
 func (s *SpacesService) Get(query url.Values) ([]byte, error) {
+	return s.GetContext(context.Background(), query)
+}
+
+func (s *SpacesService) GetContext(ctx context.Context, query url.Values) ([]byte, error) {
 	path := fmt.Sprintf(pathSpaces, s.client.Options.SpaceID)
-	return s.client.get(path, query)
+	return s.client.get(ctx, path, query)
 }
 
 func (s *SpacesService) GetSpace() (*Space, error) {
-	data, err := s.Get(nil)
+	return s.GetSpaceContext(context.Background())
+}
+
+func (s *SpacesService) GetSpaceContext(ctx context.Context) (*Space, error) {
+	data, err := s.GetContext(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +37,10 @@ func (s *SpacesService) GetSpace() (*Space, error) {
 }
 
 func (s *SpacesService) Create(body []byte) ([]byte, error) {
+	return s.CreateContext(context.Background(), body)
+}
+
+func (s *SpacesService) CreateContext(ctx context.Context, body []byte) ([]byte, error) {
 	path := pathSpacesCreate
-	s.client.headers[headerContentType] = "application/vnd.contentful.management.v1+json"
-	s.client.headers[headerContentfulOrganization] = s.client.Options.OrgID
-	return s.client.post(path, bytes.NewBuffer(body))
+	return s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
 }
@@ -1,6 +1,7 @@
 package gontentful
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -9,10 +10,14 @@ import (
 type SyncCallback func(*SyncResponse)
 
 func (s *SpacesService) Sync(token string) (*SyncResult, error) {
+	return s.SyncContext(context.Background(), token)
+}
+
+func (s *SpacesService) SyncContext(ctx context.Context, token string) (*SyncResult, error) {
 	var err error
 	res := &SyncResult{}
 
-	res.Token, err = s.SyncPaged(token, func(sr *SyncResponse) {
+	res.Token, err = s.SyncPagedContext(ctx, token, func(sr *SyncResponse) {
 		res.Items = append(res.Items, sr.Items...)
 
 	})
@@ -24,6 +29,10 @@ func (s *SpacesService) Sync(token string) (*SyncResult, error) {
 }
 
 func (s *SpacesService) SyncPaged(token string, callback SyncCallback) (string, error) {
+	return s.SyncPagedContext(context.Background(), token, callback)
+}
+
+func (s *SpacesService) SyncPagedContext(ctx context.Context, token string, callback SyncCallback) (string, error) {
 	query := url.Values{}
 	if len(token) == 0 {
 		query.Set("initial", "true")
@@ -31,7 +40,7 @@ func (s *SpacesService) SyncPaged(token string, callback SyncCallback) (string,
 		query.Set("sync_token", token)
 	}
 
-	res, err := s.getSyncPage(query)
+	res, err := s.getSyncPage(ctx, query)
 	if err != nil {
 		return "", err
 	}
@@ -43,13 +52,13 @@ func (s *SpacesService) SyncPaged(token string, callback SyncCallback) (string,
 		if err != nil {
 			return "", err
 		}
-		return s.SyncPaged(t, callback)
+		return s.SyncPagedContext(ctx, t, callback)
 	}
 
 	return getSyncToken(res.NextSyncURL)
 }
 
-func (s *SpacesService) getSyncPage(query url.Values) (*SyncResponse, error) {
+func (s *SpacesService) getSyncPage(ctx context.Context, query url.Values) (*SyncResponse, error) {
 	path := fmt.Sprintf(pathSync, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
 	// key := query.Get("sync_token")
 	// if key == "" {
@@ -66,7 +75,7 @@ func (s *SpacesService) getSyncPage(query url.Values) (*SyncResponse, error) {
 	// 	}
 	// 	return res, nil
 	// }
-	body, err := s.client.get(path, query)
+	body, err := s.client.get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,72 @@
+package gontentful_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestSyncPaging(t *testing.T) {
+	srv, client := newFake(t)
+	srv.PageSize = 2
+	for i := 0; i < 5; i++ {
+		srv.AddEntry(entry(fmt.Sprintf("e%d", i), "page", gontentful.Fields{"title": localized("t")}))
+	}
+
+	res, err := client.Spaces.Sync("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 5 {
+		t.Fatalf("initial sync returned %d items, want 5", len(res.Items))
+	}
+	if n := countRequests(srv, "/sync"); n != 3 {
+		t.Errorf("initial sync made %d requests, want 3", n)
+	}
+
+	srv.AddEntry(entry("e5", "page", gontentful.Fields{"title": localized("t")}))
+	delta, err := client.Spaces.Sync(res.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delta.Items) != 1 || delta.Items[0].Sys.ID != "e5" {
+		t.Fatalf("delta sync returned %v, want e5 only", delta.Items)
+	}
+}
+
+func TestSyncDefaultPageSize(t *testing.T) {
+	srv, client := newFake(t)
+	srv.PageSize = 0
+	srv.AddEntry(entry("a", "page", gontentful.Fields{"title": localized("t")}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := client.Spaces.SyncContext(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 1 {
+		t.Fatalf("got %d items, want 1", len(res.Items))
+	}
+}
+
+func TestSyncEntryAndAssetWithSameID(t *testing.T) {
+	srv, client := newFake(t)
+	srv.AddEntry(entry("same", "page", gontentful.Fields{"title": localized("t")}))
+	srv.AddAsset(&gontentful.Entry{Sys: &gontentful.Sys{ID: "same"}, Fields: gontentful.Fields{"title": localized("a")}})
+
+	res, err := client.Spaces.Sync("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := make(map[string]bool)
+	for _, item := range res.Items {
+		types[item.Sys.Type] = true
+	}
+	if len(res.Items) != 2 || !types[gontentful.ENTRY] || !types[gontentful.ASSET] {
+		t.Fatalf("got %d items (%v), want the entry and the asset", len(res.Items), types)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 	"text/template"
 
 	"github.com/jmoiron/sqlx"
@@ -12,7 +13,7 @@ import (
 
 var (
 	idColumns   = []string{"_id", "_sys_id"}
-	metaColumns = []string{"_locale", "_status", "_version", "_created_at", "_created_by", "_updated_at", "_updated_by", "_published_at", "_published_by"}
+	metaColumns = []string{"_locale", "_status", "_version", "_created_at", "_created_by", "_updated_at", "_updated_by", "_published_at", "_published_by", TAGS_COLUMN}
 )
 
 type PGSyncRow struct {
@@ -29,6 +30,7 @@ type PGSyncRow struct {
 	UpdatedBy    string
 	PublishedAt  *string
 	PublishedBy  *string
+	Tags         []string
 }
 
 type PGSyncTable struct {
@@ -86,9 +88,9 @@ func NewPGSyncSchema(schemaName string, locales []*Locale, types []*ContentType,
 		case ENTRY:
 			contentType := item.Sys.ContentType.Sys.ID
 			tableName := toSnakeCase(contentType)
-			appendTables(schema, item, tableName, columnsByContentType[contentType].fieldColumns, columnsByContentType[contentType].columnReferences, columnsByContentType[contentType].localizedColumns, !initSync)
+			appendTables(schema, item, tableName, columnsByContentType[contentType].fieldColumns, columnsByContentType[contentType].columnReferences, columnsByContentType[contentType].localizedColumns, columnsByContentType[contentType].richTextColumns, !initSync)
 		case ASSET:
-			appendTables(schema, item, ASSET_TABLE_NAME, assetColumns, nil, localizedAssetColumns, !initSync)
+			appendTables(schema, item, ASSET_TABLE_NAME, assetColumns, nil, localizedAssetColumns, nil, !initSync)
 			// case DELETED_ENTRY:
 			// 	contentType := item.Sys.ContentType.Sys.ID
 			// 	tableName := toSnakeCase(contentType)
@@ -139,6 +141,7 @@ func newPGSyncRow(item *Entry, fieldColumns []string, fieldValues map[string]int
 		Version:      item.Sys.Version,
 		CreatedAt:    item.Sys.CreatedAt,
 		UpdatedAt:    item.Sys.UpdatedAt,
+		Tags:         item.Metadata.TagIDs(),
 	}
 
 	if item.Sys.CreatedBy != nil {
@@ -175,10 +178,23 @@ func (r *PGSyncRow) Fields() []interface{} {
 	for _, fieldColumn := range r.FieldColumns {
 		values = append(values, r.FieldValues[fieldColumn])
 	}
-	values = append(values, r.Locale, r.Status, r.Version, r.CreatedAt, r.CreatedBy, r.UpdatedAt, r.UpdatedBy, r.PublishedAt, r.PublishedBy)
+	tags := r.Tags
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	values = append(values, r.Locale, r.Status, r.Version, r.CreatedAt, r.CreatedBy, r.UpdatedAt, r.UpdatedBy, r.PublishedAt, r.PublishedBy, pq.Array(tags))
 	return values
 }
 
+// GetTags returns the tag ids as a postgres array literal
+func (r *PGSyncRow) GetTags() string {
+	tags := make([]string, 0, len(r.Tags))
+	for _, t := range r.Tags {
+		tags = append(tags, fmt.Sprintf("'%s'", strings.ReplaceAll(t, "'", "''")))
+	}
+	return fmt.Sprintf("ARRAY[%s]::text[]", strings.Join(tags, ","))
+}
+
 func (r *PGSyncRow) GetFieldValue(fieldColumn string) string {
 	if r.FieldValues[fieldColumn] != nil {
 		return fmt.Sprintf("%v", r.FieldValues[fieldColumn])
@@ -17,7 +17,8 @@ INSERT INTO {{ $.SchemaName }}.{{ $tbl.TableName }} (
 	_updated_at,
 	_updated_by,
 	_published_at,
-	_published_by
+	_published_by,
+	_tags
 ) VALUES (
 	'{{ .ID }}',
 	'{{ .SysID }}',
@@ -32,7 +33,8 @@ INSERT INTO {{ $.SchemaName }}.{{ $tbl.TableName }} (
 	to_timestamp('{{ .UpdatedAt }}','YYYY-MM-DDThh24:mi:ssZ'),
 	'{{ if not .UpdatedBy }}sync{{ else }}{{ .UpdatedBy }}{{ end }}',
 	{{ if .PublishedAt }}to_timestamp('{{ .PublishedAt }}','YYYY-MM-DDThh24:mi:ssZ'){{ else }}NULL{{ end }},
-	{{ if and .PublishedAt .PublishedBy }}'{{ .PublishedBy }}'{{ else }}NULL{{ end }}
+	{{ if and .PublishedAt .PublishedBy }}'{{ .PublishedBy }}'{{ else }}NULL{{ end }},
+	{{ .GetTags }}
 )
 ON CONFLICT (_id) DO UPDATE
 SET
@@ -45,7 +47,8 @@ SET
 	_updated_at = EXCLUDED._updated_at,
 	_updated_by = EXCLUDED._updated_by,
 	_published_at = EXCLUDED._published_at,
-	_published_by = EXCLUDED._published_by
+	_published_by = EXCLUDED._published_by,
+	_tags = EXCLUDED._tags
 ;
 {{- end -}}
 {{- end -}}
@@ -19,9 +19,10 @@ type columnData struct {
 	fieldColumns     []string
 	columnReferences map[string]string
 	localizedColumns map[string]bool
+	richTextColumns  map[string]bool
 }
 
-func appendTables(schema *PGSyncSchema, item *Entry, tableName string, fieldColumns []string, refColumns map[string]string, localizedColumns map[string]bool, templateFormat bool) {
+func appendTables(schema *PGSyncSchema, item *Entry, tableName string, fieldColumns []string, refColumns map[string]string, localizedColumns map[string]bool, richTextColumns map[string]bool, templateFormat bool) {
 	fieldsByLocale := make(map[string][]*rowField, 0)
 	defaultLocale := schema.DefaultLocale
 	fbLocales := make(map[string]*Locale)
@@ -78,12 +79,12 @@ func appendTables(schema *PGSyncSchema, item *Entry, tableName string, fieldColu
 		// table
 		tbl := schema.Tables[tableName]
 		if tbl != nil {
-			appendRowsToTable(item, tbl, rowFields, fieldColumns, templateFormat, schema.ConTables, schema.DeletedConTables, refColumns, tableName, locale)
+			appendRowsToTable(item, tbl, rowFields, fieldColumns, templateFormat, schema.ConTables, schema.DeletedConTables, refColumns, richTextColumns, tableName, locale)
 		}
 	}
 }
 
-func appendRowsToTable(item *Entry, tbl *PGSyncTable, rowFields []*rowField, fieldColumns []string, templateFormat bool, conTables map[string]*PGSyncConTable, deletedConTables map[string]*PGSyncConTable, refColumns map[string]string, tableName string, locale string) {
+func appendRowsToTable(item *Entry, tbl *PGSyncTable, rowFields []*rowField, fieldColumns []string, templateFormat bool, conTables map[string]*PGSyncConTable, deletedConTables map[string]*PGSyncConTable, refColumns map[string]string, richTextColumns map[string]bool, tableName string, locale string) {
 	fieldValues := make(map[string]interface{})
 	id := fmtSysID(item.Sys.ID, templateFormat, locale)
 	fieldValues["_id"] = id
@@ -135,6 +136,9 @@ func appendRowsToTable(item *Entry, tbl *PGSyncTable, rowFields []*rowField, fie
 				}
 			}
 		}
+		if richTextColumns[rowField.fieldName] {
+			appendRichTextCons(conTables, deletedConTables, tableName, rowField.fieldName, rowField.fieldValue, item.Sys.ID, id, locale, templateFormat)
+		}
 		if assetFile, ok := fieldValues[rowField.fieldName].(*AssetFile); ok {
 			url := assetFile.URL
 			fileName := assetFile.FileName
@@ -168,7 +172,7 @@ func convertFieldValue(v interface{}, t bool, locale string) interface{} {
 				log.Fatal("failed to marshal content field")
 			}
 			if t {
-				return fmt.Sprintf("'%s'", string(data))
+				return fmt.Sprintf("'%s'", strings.ReplaceAll(string(data), "'", "''"))
 			}
 			return string(data)
 		}
@@ -237,7 +241,7 @@ func getColumnsByContentType(types []*ContentType) map[string]*columnData {
 	for _, t := range types {
 		if typeColumns[t.Sys.ID] == nil {
 			fieldColumns, refColumns, locColumns := getContentTypeColumns(t)
-			typeColumns[t.Sys.ID] = &columnData{fieldColumns, refColumns, locColumns}
+			typeColumns[t.Sys.ID] = &columnData{fieldColumns, refColumns, locColumns, getRichTextColumns(t)}
 		}
 	}
 	return typeColumns
@@ -264,3 +268,53 @@ func getContentTypeColumns(t *ContentType) ([]string, map[string]string, map[str
 	}
 	return fieldColumns, refColumns, localizedColumns
 }
+
+func getRichTextColumns(t *ContentType) map[string]bool {
+	richTextColumns := make(map[string]bool)
+	for _, f := range t.Fields {
+		if !f.Omitted && f.Type == RICH_TEXT {
+			richTextColumns[toSnakeCase(f.ID)] = true
+		}
+	}
+	return richTextColumns
+}
+
+// appendRichTextCons collects the entries and assets embedded in a rich text value into its con table,
+// values without links clear the con table rows of the entry
+func appendRichTextCons(conTables map[string]*PGSyncConTable, deletedConTables map[string]*PGSyncConTable, tableName string, col string, value interface{}, sysID string, id string, locale string, templateFormat bool) {
+	if id == "" {
+		return
+	}
+	conTableName := getConTableName(tableName, col)
+	var links []*Entry
+	if doc, err := ParseRichText(value); err == nil && doc != nil {
+		links = doc.Links()
+	}
+	if len(links) == 0 {
+		if deletedConTables[conTableName] == nil {
+			deletedConTables[conTableName] = &PGSyncConTable{
+				TableName: conTableName,
+				Columns:   []string{tableName},
+				Rows:      make([][]interface{}, 0),
+			}
+		}
+		deletedConTables[conTableName].Rows = append(deletedConTables[conTableName].Rows, []interface{}{id})
+		return
+	}
+	if conTables[conTableName] == nil {
+		conTables[conTableName] = &PGSyncConTable{
+			TableName: conTableName,
+			Columns:   getRichTextConColumns(tableName),
+			Rows:      make([][]interface{}, 0),
+		}
+	}
+	for _, l := range links {
+		var conRow []interface{}
+		if templateFormat {
+			conRow = []interface{}{id, fmt.Sprintf("'%s'", sysID), fmt.Sprintf("'%s'", l.Sys.LinkType), fmt.Sprintf("'%s'", l.Sys.ID), fmt.Sprintf("'%s'", locale)}
+		} else {
+			conRow = []interface{}{id, sysID, l.Sys.LinkType, l.Sys.ID, locale}
+		}
+		conTables[conTableName].Rows = append(conTables[conTableName].Rows, conRow)
+	}
+}
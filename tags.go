@@ -0,0 +1,182 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	TAG = "Tag"
+
+	TagVisibilityPublic  = "public"
+	TagVisibilityPrivate = "private"
+
+	// TAGS_COLUMN holds the tag ids of entries and assets in the postgres mirror
+	TAGS_COLUMN    = "_tags"
+	tagsQueryField = "metadata.tags.sys.id"
+)
+
+type TagSys struct {
+	Sys
+	Visibility string `json:"visibility,omitempty"`
+}
+
+type Tag struct {
+	Sys  *TagSys `json:"sys"`
+	Name string  `json:"name"`
+}
+
+type Tags struct {
+	Sys   *Sys   `json:"sys"`
+	Total int    `json:"total"`
+	Skip  int    `json:"skip"`
+	Limit int    `json:"limit"`
+	Items []*Tag `json:"items"`
+}
+
+// TagIDs returns the ids of the linked tags, it is safe to call on nil
+func (m *Metadata) TagIDs() []string {
+	ids := make([]string, 0)
+	if m == nil {
+		return ids
+	}
+	for _, t := range m.Tags {
+		if t != nil && t.Sys != nil {
+			ids = append(ids, t.Sys.ID)
+		}
+	}
+	return ids
+}
+
+// NewMetadata links the tags with the given ids
+func NewMetadata(tagIDs ...string) *Metadata {
+	m := &Metadata{
+		Tags: make([]*Entry, 0, len(tagIDs)),
+	}
+	for _, id := range tagIDs {
+		m.Tags = append(m.Tags, &Entry{
+			Sys: &Sys{
+				Type:     LINK,
+				LinkType: TAG,
+				ID:       id,
+			},
+		})
+	}
+	return m
+}
+
+type TagsService service
+
+func (s *TagsService) GetTags(query url.Values) (*Tags, error) {
+	return s.GetTagsContext(context.Background(), query)
+}
+
+// GetTagsContext lists the public tags on the delivery api
+func (s *TagsService) GetTagsContext(ctx context.Context, query url.Values) (*Tags, error) {
+	path := fmt.Sprintf(pathTags, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTags(data)
+}
+
+func (s *TagsService) GetCMATags(query url.Values) (*Tags, error) {
+	return s.GetCMATagsContext(context.Background(), query)
+}
+
+// GetCMATagsContext lists public and private tags on the management api
+func (s *TagsService) GetCMATagsContext(ctx context.Context, query url.Values) (*Tags, error) {
+	path := fmt.Sprintf(pathTags, s.client.Options.SpaceID, s.client.Options.EnvironmentID)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTags(data)
+}
+
+func (s *TagsService) GetSingle(tagId string) (*Tag, error) {
+	return s.GetSingleContext(context.Background(), tagId)
+}
+
+func (s *TagsService) GetSingleContext(ctx context.Context, tagId string) (*Tag, error) {
+	path := fmt.Sprintf(pathTag, s.client.Options.SpaceID, s.client.Options.EnvironmentID, tagId)
+	data, err := s.client.get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTag(data)
+}
+
+// Create creates a tag, visibility is public or private and cannot be changed later
+func (s *TagsService) Create(tagId string, name string, visibility string) (*Tag, error) {
+	return s.CreateContext(context.Background(), tagId, name, visibility)
+}
+
+func (s *TagsService) CreateContext(ctx context.Context, tagId string, name string, visibility string) (*Tag, error) {
+	body, err := json.Marshal(&Tag{
+		Sys: &TagSys{
+			Sys:        Sys{ID: tagId, Type: TAG},
+			Visibility: visibility,
+		},
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathTag, s.client.Options.SpaceID, s.client.Options.EnvironmentID, tagId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTag(data)
+}
+
+// Update renames a tag
+func (s *TagsService) Update(version string, tagId string, name string) (*Tag, error) {
+	return s.UpdateContext(context.Background(), version, tagId, name)
+}
+
+func (s *TagsService) UpdateContext(ctx context.Context, version string, tagId string, name string) (*Tag, error) {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathTag, s.client.Options.SpaceID, s.client.Options.EnvironmentID, tagId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalTag(data)
+}
+
+func (s *TagsService) Delete(version string, tagId string) error {
+	return s.DeleteContext(context.Background(), version, tagId)
+}
+
+func (s *TagsService) DeleteContext(ctx context.Context, version string, tagId string) error {
+	path := fmt.Sprintf(pathTag, s.client.Options.SpaceID, s.client.Options.EnvironmentID, tagId)
+	_, err := s.client.delete(ctx, path, withVersion(version))
+	return err
+}
+
+func unmarshalTag(data []byte) (*Tag, error) {
+	res := &Tag{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func unmarshalTags(data []byte) (*Tags, error) {
+	res := &Tags{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
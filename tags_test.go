@@ -0,0 +1,134 @@
+package gontentful_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moonwalker/gontentful"
+)
+
+func TestMetadataTagIDs(t *testing.T) {
+	var m *gontentful.Metadata
+	if ids := m.TagIDs(); ids == nil || len(ids) != 0 {
+		t.Errorf("got %v from nil metadata", ids)
+	}
+
+	m = gontentful.NewMetadata("a", "b")
+	if ids := m.TagIDs(); !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("got %v", ids)
+	}
+	for _, tag := range m.Tags {
+		if tag.Sys.Type != gontentful.LINK || tag.Sys.LinkType != gontentful.TAG {
+			t.Errorf("got %+v", tag.Sys)
+		}
+	}
+}
+
+func TestTagsPGFilters(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		query *gontentful.Query
+		want  string
+	}{
+		{"any", gontentful.NewQuery().Tags("a", "b"), "_tags && ARRAY[''a'',''b'']::text[]"},
+		{"all", gontentful.NewQuery().AllTags("a", "b"), "_tags @> ARRAY[''a'',''b'']::text[]"},
+		{"none", gontentful.NewQuery().NotIn("metadata.tags.sys.id", "a"), "NOT (_tags && ARRAY[''a'']::text[])"},
+		{"quote", gontentful.NewQuery().Tags("o'neil"), "_tags && ARRAY[''o''''neil'']::text[]"},
+		{"exists", gontentful.NewQuery().Exists("metadata.tags.sys.id", true), "cardinality(_tags) > 0"},
+		{"not exists", gontentful.NewQuery().Exists("metadata.tags.sys.id", false), "cardinality(_tags) = 0"},
+	} {
+		got := pgFilters(tc.query)
+		if len(got) != 1 || got[0] != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTagsColumns(t *testing.T) {
+	schema := gameSchema(&gontentful.Locale{Code: "en", Default: true})
+
+	sql, err := schema.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sql, "ALTER TABLE game ADD COLUMN IF NOT EXISTS _tags text[] not null default '{}';") {
+		t.Error("schema is missing the _tags column")
+	}
+
+	sql = renderFunctions(t, schema)
+	for _, s := range []string{
+		"DROP FUNCTION game_view(text) CASCADE;",
+		", _tags text[]) AS $$",
+		"game._tags AS _tags",
+	} {
+		if !strings.Contains(sql, s) {
+			t.Errorf("functions are missing %s", s)
+		}
+	}
+	// the view function is replaced in place only when its columns are unchanged
+	if drop, create := strings.Index(sql, "DROP FUNCTION game_view"), strings.Index(sql, "CREATE OR REPLACE FUNCTION game_view"); drop < 0 || drop > create {
+		t.Error("the view function is not dropped before it is replaced")
+	}
+	if !strings.Contains(sql, "ARRAY['localearg', '_id', '_sys_id', 'name', '_created_at', '_updated_at', '_tags']") {
+		t.Error("the view function is not dropped on changed columns")
+	}
+}
+
+func TestTagsService(t *testing.T) {
+	var method, path, version, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		method, path, version, body = r.Method, r.URL.Path, r.Header.Get("X-Contentful-Version"), string(b)
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"total":1,"items":[{"sys":{"id":"vip","type":"Tag","visibility":"private"},"name":"VIP"}]}`))
+			return
+		}
+		w.Write([]byte(`{"sys":{"id":"vip","type":"Tag","version":1,"visibility":"private"},"name":"VIP"}`))
+	}))
+	defer srv.Close()
+
+	client := gontentful.NewClient(&gontentful.ClientOptions{
+		SpaceID:       "space",
+		EnvironmentID: "master",
+		CdnURL:        srv.URL,
+		CmaURL:        srv.URL,
+	})
+
+	tag, err := client.Tags.Create("vip", "VIP", gontentful.TagVisibilityPrivate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || path != "/spaces/space/environments/master/tags/vip" {
+		t.Errorf("got %s %s", method, path)
+	}
+	var sent gontentful.Tag
+	if err := json.Unmarshal([]byte(body), &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Name != "VIP" || sent.Sys.ID != "vip" || sent.Sys.Visibility != gontentful.TagVisibilityPrivate {
+		t.Errorf("sent %s", body)
+	}
+	if tag.Sys.Version != 1 || tag.Sys.Visibility != gontentful.TagVisibilityPrivate {
+		t.Errorf("got %+v", tag.Sys)
+	}
+
+	if _, err := client.Tags.Update("1", "vip", "Very VIP"); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPut || version != "1" || body != `{"name":"Very VIP"}` {
+		t.Errorf("got %s version %q body %s", method, version, body)
+	}
+
+	tags, err := client.Tags.GetCMATags(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Total != 1 || tags.Items[0].Sys.Visibility != gontentful.TagVisibilityPrivate {
+		t.Errorf("got %+v", tags)
+	}
+}
@@ -426,6 +426,10 @@ func TransformEntry(locales []*Locale, model *Entry, brand string, fmtVideoURL f
 			}
 		}
 
+		if tags := model.Metadata.TagIDs(); len(tags) > 0 {
+			data.Fields[TAGS_COLUMN] = tags
+		}
+
 		data.CreatedAt = model.Sys.CreatedAt
 		data.CreatedBy = "admin"
 		data.UpdatedAt = model.Sys.UpdatedAt
@@ -487,6 +491,10 @@ func TransformPublishedEntry(locales []*Locale, model *PublishedEntry, localized
 			}
 		}
 
+		if tags := model.Metadata.TagIDs(); len(tags) > 0 {
+			data.Fields[TAGS_COLUMN] = tags
+		}
+
 		data.CreatedAt = model.Sys.CreatedAt
 		data.CreatedBy = "admin"
 		data.UpdatedAt = model.Sys.UpdatedAt
@@ -580,11 +588,15 @@ func formatEntry(id string, contentType string, contents map[string]content.Cont
 			}}
 	}
 
+	if tags := contentTags(contents[DefaultLocale].Fields[TAGS_COLUMN]); len(tags) > 0 {
+		e.Metadata = NewMetadata(tags...)
+	}
+
 	fields := make(map[string]interface{})
 
 	for loc, data := range contents {
 		for fn, fv := range data.Fields {
-			if fv == nil {
+			if fv == nil || fn == TAGS_COLUMN {
 				continue
 			}
 			if fields[fn] == nil {
@@ -629,6 +641,22 @@ func formatEntry(id string, contentType string, contents map[string]content.Cont
 	return e, includes
 }
 
+func contentTags(v interface{}) []string {
+	switch t := v.(type) {
+	case []string:
+		return t
+	case []interface{}:
+		tags := make([]string, 0, len(t))
+		for _, tag := range t {
+			if id, ok := tag.(string); ok {
+				tags = append(tags, id)
+			}
+		}
+		return tags
+	}
+	return nil
+}
+
 func replaceAssetFile(brand string, file interface{}, sysID string, loc string, fmtVideoURL func(string) string) interface{} {
 	if originalfileMap, ok := file.(map[string]interface{}); ok {
 		// clone map
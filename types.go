@@ -19,6 +19,9 @@ type Sys struct {
 	PublishedBy      *Entry       `json:"publishedBy,omitempty"`
 	PublishedVersion int          `json:"publishedVersion,omitempty"`
 	Space            *Space       `json:"space,omitempty"`
+	Locale           string       `json:"locale,omitempty"`
+	ArchivedAt       string       `json:"archivedAt,omitempty"`
+	ArchivedVersion  int          `json:"archivedVersion,omitempty"`
 }
 
 type Entries struct {
@@ -38,9 +41,16 @@ type Include struct {
 type Fields map[string]interface{}
 
 type Entry struct {
-	Sys    *Sys   `json:"sys"`
-	Locale string `json:"locale,omitempty"`
-	Fields Fields `json:"fields,omitempty"` // fields are dynamic
+	Sys      *Sys      `json:"sys"`
+	Locale   string    `json:"locale,omitempty"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+	Fields   Fields    `json:"fields,omitempty"` // fields are dynamic
+}
+
+// Metadata holds the tags and taxonomy concepts of entries and assets as links
+type Metadata struct {
+	Tags     []*Entry `json:"tags"`
+	Concepts []*Entry `json:"concepts,omitempty"`
 }
 
 type Space struct {
@@ -65,11 +75,18 @@ type Locales struct {
 }
 
 type Locale struct {
-	Code         string   `json:"code"`
-	Default      bool     `json:"default"`
-	Name         string   `json:"name"`
-	FallbackCode string   `json:"fallbackCode"`
-	CFLocales    []string `json:"cfFallbackCode"`
+	Sys          *Sys   `json:"sys,omitempty"`
+	Code         string `json:"code"`
+	Default      bool   `json:"default"`
+	Name         string `json:"name"`
+	FallbackCode string `json:"fallbackCode"`
+	// Optional, ContentDeliveryAPI and ContentManagementAPI are only returned by the management api,
+	// nil api flags are left to Contentful on writes, which enables the locale on both apis
+	Optional             bool  `json:"optional"`
+	ContentDeliveryAPI   *bool `json:"contentDeliveryApi,omitempty"`
+	ContentManagementAPI *bool `json:"contentManagementApi,omitempty"`
+	// Deprecated: CFLocales are read into PGSQLSchema.LocaleAliases by NewPGSQLSchema, set those instead
+	CFLocales []string `json:"cfFallbackCode,omitempty"`
 }
 
 type ContentType struct {
@@ -144,29 +161,53 @@ type SyncResult struct {
 	Token string
 }
 
+type Asset struct {
+	Sys      *Sys         `json:"sys,omitempty"`
+	Metadata *Metadata    `json:"metadata,omitempty"`
+	Fields   *AssetFields `json:"fields,omitempty"`
+}
+
+type Assets struct {
+	Sys   *Sys     `json:"sys"`
+	Total int      `json:"total"`
+	Skip  int      `json:"skip"`
+	Limit int      `json:"limit"`
+	Items []*Asset `json:"items"`
+}
+
+// AssetFields are keyed by locale code, assets fetched from the delivery api
+// in a single locale are stored under sys.locale
 type AssetFields struct {
-	Title map[string]string
-	File  map[string]*AssetFile
+	Title       map[string]string     `json:"title,omitempty"`
+	Description map[string]string     `json:"description,omitempty"`
+	File        map[string]*AssetFile `json:"file,omitempty"`
 }
 
 type AssetFile struct {
-	URL         string `json:"url"`
-	FileName    string `json:"fileName"`
-	ContentType string `json:"contentType"`
-	Details     struct {
-		Size  int `json:"size"`
-		Image struct {
-			Width  int `json:"width"`
-			Height int `json:"height"`
-		} `json:"image"`
-	} `json:"details"`
+	URL         string           `json:"url,omitempty"`
+	FileName    string           `json:"fileName,omitempty"`
+	ContentType string           `json:"contentType,omitempty"`
+	Upload      string           `json:"upload,omitempty"`
+	UploadFrom  *Entry           `json:"uploadFrom,omitempty"`
+	Details     AssetFileDetails `json:"details"`
+}
+
+type AssetFileDetails struct {
+	Size  int               `json:"size"`
+	Image AssetImageDetails `json:"image"`
+}
+
+type AssetImageDetails struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
 }
 
 type PublishFields map[string]map[string]interface{}
 
 type PublishedEntry struct {
-	Sys    *Sys          `json:"sys"`
-	Fields PublishFields `json:"fields"`
+	Sys      *Sys          `json:"sys"`
+	Metadata *Metadata     `json:"metadata,omitempty"`
+	Fields   PublishFields `json:"fields"`
 }
 
 type PublishedEntries struct {
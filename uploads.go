@@ -1,16 +1,54 @@
 package gontentful
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 )
 
+const UPLOAD = "Upload"
+
+type Upload struct {
+	Sys *Sys `json:"sys"`
+}
+
 type UploadsService service
 
 func (s *UploadsService) Create(data io.Reader) ([]byte, error) {
+	return s.CreateContext(context.Background(), data)
+}
+
+func (s *UploadsService) CreateContext(ctx context.Context, data io.Reader) ([]byte, error) {
 	path := fmt.Sprintf(pathUploads, s.client.Options.SpaceID)
-	// Set header for content type
-	s.client.headers[headerContentType] = "application/octet-stream"
+	return s.client.post(ctx, path, data, withContentType(mimeOctetStream))
+}
+
+// CreateUpload streams data to the upload api, seekable readers can be resent on retries
+func (s *UploadsService) CreateUpload(data io.Reader) (*Upload, error) {
+	return s.CreateUploadContext(context.Background(), data)
+}
+
+func (s *UploadsService) CreateUploadContext(ctx context.Context, data io.Reader) (*Upload, error) {
+	body, err := s.CreateContext(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	res := &Upload{}
+	err = json.Unmarshal(body, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
-	return s.client.post(path, data)
+// Link returns the link to use as the uploadFrom of an asset file
+func (u *Upload) Link() *Entry {
+	return &Entry{
+		Sys: &Sys{
+			Type:     "Link",
+			LinkType: UPLOAD,
+			ID:       u.Sys.ID,
+		},
+	}
 }
@@ -0,0 +1,264 @@
+package gontentful
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	headerWebhookTopic         = "X-Contentful-Topic"
+	headerWebhookName          = "X-Contentful-Webhook-Name"
+	headerWebhookSignature     = "X-Contentful-Signature"
+	headerWebhookSignedHeaders = "X-Contentful-Signed-Headers"
+	headerWebhookTimestamp     = "X-Contentful-Timestamp"
+
+	defaultWebhookTTL   = 30 * time.Second
+	maxWebhookBodyBytes = 10 << 20
+)
+
+// webhook topics, Type.Action as in the X-Contentful-Topic header without the ContentManagement. prefix
+const (
+	TopicEntryCreate          = "Entry.create"
+	TopicEntrySave            = "Entry.save"
+	TopicEntryAutoSave        = "Entry.auto_save"
+	TopicEntryArchive         = "Entry.archive"
+	TopicEntryUnarchive       = "Entry.unarchive"
+	TopicEntryPublish         = "Entry.publish"
+	TopicEntryUnpublish       = "Entry.unpublish"
+	TopicEntryDelete          = "Entry.delete"
+	TopicAssetCreate          = "Asset.create"
+	TopicAssetSave            = "Asset.save"
+	TopicAssetAutoSave        = "Asset.auto_save"
+	TopicAssetArchive         = "Asset.archive"
+	TopicAssetUnarchive       = "Asset.unarchive"
+	TopicAssetPublish         = "Asset.publish"
+	TopicAssetUnpublish       = "Asset.unpublish"
+	TopicAssetDelete          = "Asset.delete"
+	TopicContentTypeCreate    = "ContentType.create"
+	TopicContentTypeSave      = "ContentType.save"
+	TopicContentTypePublish   = "ContentType.publish"
+	TopicContentTypeUnpublish = "ContentType.unpublish"
+	TopicContentTypeDelete    = "ContentType.delete"
+)
+
+var (
+	ErrWebhookSignature = errors.New("invalid webhook signature")
+	ErrWebhookExpired   = errors.New("webhook request expired")
+)
+
+// WebhookEvent is a webhook call parsed from the topic header and the payload
+type WebhookEvent struct {
+	// Topic is Type.Action, e.g. Entry.publish
+	Topic  string
+	Type   string
+	Action string
+	// Webhook is the name of the webhook definition
+	Webhook string
+	// Sys of the payload, for the entity the event is about
+	Sys  *Sys
+	Body []byte
+}
+
+// Entry decodes the payload of Entry and Asset events
+func (e *WebhookEvent) Entry() (*Entry, error) {
+	res := &Entry{}
+	if err := json.Unmarshal(e.Body, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (e *WebhookEvent) Asset() (*Asset, error) {
+	return unmarshalAsset(e.Body)
+}
+
+func (e *WebhookEvent) ContentType() (*ContentType, error) {
+	res := &ContentType{}
+	if err := json.Unmarshal(e.Body, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type WebhookFunc func(r *http.Request, e *WebhookEvent) error
+
+// WebhookHandler receives Contentful webhook calls, verifies their signature
+// and dispatches them to the callbacks registered for their topic
+//
+//	h := gontentful.NewWebhookHandler(secret)
+//	h.On(gontentful.TopicEntryPublish, func(r *http.Request, e *gontentful.WebhookEvent) error {
+//		entry, err := e.Entry()
+//		...
+//	})
+//	http.Handle("/webhooks", h)
+type WebhookHandler struct {
+	// Secret is the signing secret of the space, every request must carry a valid signature
+	// made with it. An empty secret disables verification and accepts any request.
+	Secret string
+	// TTL is how old a signed request may be, 30 seconds by default
+	TTL time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string][]WebhookFunc
+}
+
+// NewWebhookHandler verifies requests with the signing secret, an empty secret disables verification
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{
+		Secret:   secret,
+		handlers: make(map[string][]WebhookFunc),
+	}
+}
+
+// On registers fn for a topic, * matches any type or action (Entry.*, *.publish, *.*)
+func (h *WebhookHandler) On(topic string, fn WebhookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[topic] = append(h.handlers[topic], fn)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.Secret != "" {
+		if err := VerifyWebhookRequest(r, body, h.Secret, h.TTL); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	e, err := ParseWebhookEvent(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, fn := range h.match(e) {
+		if err := fn(r, e); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) match(e *WebhookEvent) []WebhookFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	res := make([]WebhookFunc, 0)
+	for _, topic := range []string{e.Topic, e.Type + ".*", "*." + e.Action, "*.*"} {
+		res = append(res, h.handlers[topic]...)
+	}
+	return res
+}
+
+// ParseWebhookEvent reads the topic header and the sys of the payload
+func ParseWebhookEvent(r *http.Request, body []byte) (*WebhookEvent, error) {
+	topic := r.Header.Get(headerWebhookTopic)
+	parts := strings.Split(topic, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid webhook topic: %q", topic)
+	}
+
+	e := &WebhookEvent{
+		Topic:   parts[1] + "." + parts[2],
+		Type:    parts[1],
+		Action:  parts[2],
+		Webhook: r.Header.Get(headerWebhookName),
+		Body:    body,
+	}
+	var payload struct {
+		Sys *Sys `json:"sys"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	e.Sys = payload.Sys
+	return e, nil
+}
+
+// VerifyWebhookRequest checks the request signature made with the signing secret
+// of the space and rejects requests older than ttl (30 seconds when zero). The
+// timestamp must be among the signed headers, so it cannot be replaced on replay.
+func VerifyWebhookRequest(r *http.Request, body []byte, secret string, ttl time.Duration) error {
+	signature := r.Header.Get(headerWebhookSignature)
+	if signature == "" || secret == "" {
+		return ErrWebhookSignature
+	}
+	signedHeaders := strings.Split(r.Header.Get(headerWebhookSignedHeaders), ",")
+	timestampSigned := false
+	for _, key := range signedHeaders {
+		if strings.EqualFold(strings.TrimSpace(key), headerWebhookTimestamp) {
+			timestampSigned = true
+		}
+	}
+	if !timestampSigned {
+		return ErrWebhookSignature
+	}
+	expected := webhookSignature(r, body, secret, signedHeaders)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrWebhookSignature
+	}
+
+	if ttl <= 0 {
+		ttl = defaultWebhookTTL
+	}
+	ms, err := strconv.ParseInt(r.Header.Get(headerWebhookTimestamp), 10, 64)
+	if err != nil {
+		return ErrWebhookSignature
+	}
+	if time.Since(time.UnixMilli(ms)) > ttl {
+		return ErrWebhookExpired
+	}
+	return nil
+}
+
+// SignWebhookRequest signs a request the way Contentful does, for testing receivers
+func SignWebhookRequest(r *http.Request, body []byte, secret string) {
+	r.Header.Set(headerWebhookTimestamp, strconv.FormatInt(time.Now().UnixMilli(), 10))
+	signed := []string{strings.ToLower(headerWebhookTimestamp), strings.ToLower(headerWebhookSignedHeaders)}
+	r.Header.Set(headerWebhookSignedHeaders, strings.Join(signed, ","))
+	r.Header.Set(headerWebhookSignature, webhookSignature(r, body, secret, signed))
+}
+
+// webhookSignature is the hex HMAC-SHA256 of the canonical request:
+// method, path with query, the signed headers as key:value joined by ; and the body, separated by newlines
+func webhookSignature(r *http.Request, body []byte, secret string, signedHeaders []string) string {
+	headers := make([]string, 0, len(signedHeaders))
+	for _, key := range signedHeaders {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		headers = append(headers, key+":"+strings.TrimSpace(r.Header.Get(key)))
+	}
+	canonical := strings.Join([]string{
+		r.Method,
+		r.URL.RequestURI(),
+		strings.Join(headers, ";"),
+		string(body),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,68 @@
+package gontentful_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moonwalker/gontentful"
+)
+
+const webhookBody = `{"sys":{"id":"a","type":"Entry"}}`
+
+func webhookRequest(secret string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(webhookBody))
+	r.Header.Set("X-Contentful-Topic", "ContentManagement.Entry.publish")
+	if secret != "" {
+		gontentful.SignWebhookRequest(r, []byte(webhookBody), secret)
+	}
+	return r
+}
+
+func serveWebhook(h *gontentful.WebhookHandler, r *http.Request) (int, int) {
+	calls := 0
+	h.On(gontentful.TopicEntryPublish, func(r *http.Request, e *gontentful.WebhookEvent) error {
+		calls++
+		return nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Code, calls
+}
+
+func TestWebhookHandlerVerifiesSignature(t *testing.T) {
+	if code, calls := serveWebhook(gontentful.NewWebhookHandler("secret"), webhookRequest("secret")); code != http.StatusOK || calls != 1 {
+		t.Errorf("signed request: got %d with %d calls", code, calls)
+	}
+	if code, calls := serveWebhook(gontentful.NewWebhookHandler("secret"), webhookRequest("")); code != http.StatusUnauthorized || calls != 0 {
+		t.Errorf("unsigned request: got %d with %d calls", code, calls)
+	}
+	if code, calls := serveWebhook(gontentful.NewWebhookHandler("secret"), webhookRequest("other")); code != http.StatusUnauthorized || calls != 0 {
+		t.Errorf("request signed with another secret: got %d with %d calls", code, calls)
+	}
+}
+
+func TestWebhookHandlerRequiresSignedTimestamp(t *testing.T) {
+	// validly signed, but without the timestamp among the signed headers
+	r := webhookRequest("")
+	r.Header.Set("X-Contentful-Timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	r.Header.Set("X-Contentful-Signed-Headers", "x-contentful-signed-headers")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("POST\n/webhooks\nx-contentful-signed-headers:x-contentful-signed-headers\n" + webhookBody))
+	r.Header.Set("X-Contentful-Signature", hex.EncodeToString(mac.Sum(nil)))
+	if code, calls := serveWebhook(gontentful.NewWebhookHandler("secret"), r); code != http.StatusUnauthorized || calls != 0 {
+		t.Errorf("got %d with %d calls", code, calls)
+	}
+}
+
+func TestWebhookHandlerWithoutSecret(t *testing.T) {
+	if code, calls := serveWebhook(gontentful.NewWebhookHandler(""), webhookRequest("")); code != http.StatusOK || calls != 1 {
+		t.Errorf("got %d with %d calls", code, calls)
+	}
+}
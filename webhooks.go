@@ -0,0 +1,230 @@
+package gontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	WEBHOOK = "WebhookDefinition"
+)
+
+type Webhook struct {
+	Sys               *Sys                   `json:"sys,omitempty"`
+	Name              string                 `json:"name"`
+	URL               string                 `json:"url"`
+	Topics            []string               `json:"topics"`
+	Filters           []interface{}          `json:"filters,omitempty"`
+	Active            *bool                  `json:"active,omitempty"`
+	HTTPBasicUsername string                 `json:"httpBasicUsername,omitempty"`
+	HTTPBasicPassword string                 `json:"httpBasicPassword,omitempty"`
+	Headers           []*WebhookHeader       `json:"headers,omitempty"`
+	Transformation    *WebhookTransformation `json:"transformation,omitempty"`
+}
+
+type WebhookHeader struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+type WebhookTransformation struct {
+	Method               string      `json:"method,omitempty"`
+	ContentType          string      `json:"contentType,omitempty"`
+	IncludeContentLength bool        `json:"includeContentLength,omitempty"`
+	Body                 interface{} `json:"body,omitempty"`
+}
+
+type Webhooks struct {
+	Sys   *Sys       `json:"sys"`
+	Total int        `json:"total"`
+	Skip  int        `json:"skip"`
+	Limit int        `json:"limit"`
+	Items []*Webhook `json:"items"`
+}
+
+// WebhookCall is the overview of a call in the call log
+type WebhookCall struct {
+	Sys        *Sys     `json:"sys"`
+	StatusCode int      `json:"statusCode"`
+	Errors     []string `json:"errors"`
+	EventType  string   `json:"eventType"`
+	URL        string   `json:"url"`
+	RequestAt  string   `json:"requestAt"`
+	ResponseAt string   `json:"responseAt"`
+	// Request and Response are only returned for a single call
+	Request  *WebhookCallMessage `json:"request,omitempty"`
+	Response *WebhookCallMessage `json:"response,omitempty"`
+}
+
+type WebhookCallMessage struct {
+	URL        string            `json:"url,omitempty"`
+	Method     string            `json:"method,omitempty"`
+	StatusCode int               `json:"statusCode,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type WebhookCalls struct {
+	Sys   *Sys           `json:"sys"`
+	Total int            `json:"total"`
+	Skip  int            `json:"skip"`
+	Limit int            `json:"limit"`
+	Items []*WebhookCall `json:"items"`
+}
+
+// WebhooksService manages the webhooks of the space on the CMA
+type WebhooksService service
+
+func (s *WebhooksService) GetWebhooks() (*Webhooks, error) {
+	return s.GetWebhooksContext(context.Background())
+}
+
+// GetWebhooksContext returns the first page of webhooks, at most 100
+func (s *WebhooksService) GetWebhooksContext(ctx context.Context) (*Webhooks, error) {
+	return s.getWebhooksContext(ctx, nil)
+}
+
+func (s *WebhooksService) getWebhooksContext(ctx context.Context, query url.Values) (*Webhooks, error) {
+	path := fmt.Sprintf(pathWebhooks, s.client.Options.SpaceID)
+	data, err := s.client.getCMA(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	res := &Webhooks{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *WebhooksService) GetSingle(webhookId string) (*Webhook, error) {
+	return s.GetSingleContext(context.Background(), webhookId)
+}
+
+func (s *WebhooksService) GetSingleContext(ctx context.Context, webhookId string) (*Webhook, error) {
+	path := fmt.Sprintf(pathWebhook, s.client.Options.SpaceID, webhookId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalWebhook(data)
+}
+
+func (s *WebhooksService) Create(webhook *Webhook) (*Webhook, error) {
+	return s.CreateContext(context.Background(), webhook)
+}
+
+func (s *WebhooksService) CreateContext(ctx context.Context, webhook *Webhook) (*Webhook, error) {
+	body, err := webhookBody(webhook)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathWebhooks, s.client.Options.SpaceID)
+	data, err := s.client.post(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalWebhook(data)
+}
+
+func (s *WebhooksService) Update(version string, webhookId string, webhook *Webhook) (*Webhook, error) {
+	return s.UpdateContext(context.Background(), version, webhookId, webhook)
+}
+
+func (s *WebhooksService) UpdateContext(ctx context.Context, version string, webhookId string, webhook *Webhook) (*Webhook, error) {
+	body, err := webhookBody(webhook)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(pathWebhook, s.client.Options.SpaceID, webhookId)
+	data, err := s.client.put(ctx, path, bytes.NewBuffer(body), withVersion(version), withContentType(mimeManagement))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalWebhook(data)
+}
+
+func (s *WebhooksService) Delete(webhookId string) error {
+	return s.DeleteContext(context.Background(), webhookId)
+}
+
+func (s *WebhooksService) DeleteContext(ctx context.Context, webhookId string) error {
+	path := fmt.Sprintf(pathWebhook, s.client.Options.SpaceID, webhookId)
+	_, err := s.client.delete(ctx, path)
+	return err
+}
+
+// GetCalls lists the most recent calls of a webhook
+func (s *WebhooksService) GetCalls(webhookId string) (*WebhookCalls, error) {
+	return s.GetCallsContext(context.Background(), webhookId)
+}
+
+func (s *WebhooksService) GetCallsContext(ctx context.Context, webhookId string) (*WebhookCalls, error) {
+	path := fmt.Sprintf(pathWebhookCalls, s.client.Options.SpaceID, webhookId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &WebhookCalls{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetCall returns a call with its request and response
+func (s *WebhooksService) GetCall(webhookId string, callId string) (*WebhookCall, error) {
+	return s.GetCallContext(context.Background(), webhookId, callId)
+}
+
+func (s *WebhooksService) GetCallContext(ctx context.Context, webhookId string, callId string) (*WebhookCall, error) {
+	path := fmt.Sprintf(pathWebhookCall, s.client.Options.SpaceID, webhookId, callId)
+	data, err := s.client.getCMA(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &WebhookCall{}
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetSigningSecret sets the secret Contentful signs the webhook requests of the space with,
+// see WebhookHandler
+func (s *WebhooksService) SetSigningSecret(secret string) error {
+	return s.SetSigningSecretContext(context.Background(), secret)
+}
+
+func (s *WebhooksService) SetSigningSecretContext(ctx context.Context, secret string) error {
+	body, err := json.Marshal(map[string]string{"value": secret})
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf(pathWebhookSecret, s.client.Options.SpaceID)
+	_, err = s.client.put(ctx, path, bytes.NewBuffer(body), withContentType(mimeManagement))
+	return err
+}
+
+// webhookBody leaves sys out of the payload
+func webhookBody(webhook *Webhook) ([]byte, error) {
+	w := *webhook
+	w.Sys = nil
+	return json.Marshal(&w)
+}
+
+func unmarshalWebhook(data []byte) (*Webhook, error) {
+	res := &Webhook{}
+	err := json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
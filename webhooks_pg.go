@@ -0,0 +1,149 @@
+package gontentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// PGWebhooks applies webhook events to a postgres schema: publish upserts
+// entries and assets, unpublish, archive and delete remove them, content type
+// publish and delete update the tables of the content type
+type PGWebhooks struct {
+	DatabaseURL string
+	SchemaName  string
+	Locales     []*Locale
+	// RefreshMatViews refreshes the materialized views depending on the changed table
+	RefreshMatViews bool
+
+	mu    sync.Mutex
+	types map[string]*ContentType
+}
+
+// NewPGWebhooks takes the management api content types, kept up to date by content type events
+func NewPGWebhooks(databaseURL string, schemaName string, locales []*Locale, types []*ContentType) *PGWebhooks {
+	s := &PGWebhooks{
+		DatabaseURL: databaseURL,
+		SchemaName:  schemaName,
+		Locales:     locales,
+		types:       make(map[string]*ContentType),
+	}
+	for _, t := range types {
+		s.types[t.Sys.ID] = t
+	}
+	return s
+}
+
+// Apply runs the postgres changes of an event, events are applied one at a time
+// and topics without a postgres change are ignored. The returned message
+// describes the materialized view refresh started in the background, if any.
+func (s *PGWebhooks) Apply(e *WebhookEvent) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch e.Type {
+	case ENTRY, ASSET:
+		switch e.Action {
+		case "publish":
+			return s.publish(e)
+		case "unpublish", "archive", "delete":
+			return s.delete(e)
+		}
+	case CONTENT_TYPE:
+		switch e.Action {
+		case "publish":
+			return "", s.publishContentType(e)
+		case "unpublish", "delete":
+			return "", s.deleteContentType(e)
+		}
+	}
+	return "", nil
+}
+
+func (s *PGWebhooks) publish(e *WebhookEvent) (string, error) {
+	item := &PublishedEntry{}
+	if err := json.Unmarshal(e.Body, item); err != nil {
+		return "", err
+	}
+	if item.Sys == nil {
+		return "", fmt.Errorf("missing sys in %s payload", e.Topic)
+	}
+
+	var contentModel *ContentType
+	tableName := ASSET_TABLE_NAME
+	if e.Type == ENTRY {
+		if item.Sys.ContentType == nil || item.Sys.ContentType.Sys == nil {
+			return "", fmt.Errorf("missing content type of entry %s", item.Sys.ID)
+		}
+		tableName = item.Sys.ContentType.Sys.ID
+		contentModel = s.types[tableName]
+		if contentModel == nil {
+			return "", fmt.Errorf("unknown content type %s of entry %s", tableName, item.Sys.ID)
+		}
+	}
+
+	err := NewPGPublish(s.SchemaName, s.Locales, contentModel, item, "published").Exec(s.DatabaseURL)
+	if err != nil {
+		return "", err
+	}
+	return s.refresh(tableName)
+}
+
+func (s *PGWebhooks) delete(e *WebhookEvent) (string, error) {
+	if e.Sys == nil {
+		return "", fmt.Errorf("missing sys in %s payload", e.Topic)
+	}
+	sys := *e.Sys
+	tableName := ASSET_TABLE_NAME
+	sys.Type = DELETED_ASSET
+	if e.Type == ENTRY {
+		if sys.ContentType == nil || sys.ContentType.Sys == nil {
+			return "", fmt.Errorf("missing content type of entry %s", sys.ID)
+		}
+		tableName = sys.ContentType.Sys.ID
+		sys.Type = DELETED_ENTRY
+	}
+
+	err := NewPGDelete(s.SchemaName, &sys).Exec(s.DatabaseURL)
+	if err != nil {
+		return "", err
+	}
+	return s.refresh(tableName)
+}
+
+func (s *PGWebhooks) publishContentType(e *WebhookEvent) error {
+	ct, err := e.ContentType()
+	if err != nil {
+		return err
+	}
+	if ct.Sys == nil {
+		return fmt.Errorf("missing sys in %s payload", e.Topic)
+	}
+	s.types[ct.Sys.ID] = ct
+
+	types := make([]*ContentType, 0, len(s.types))
+	for _, t := range s.types {
+		types = append(types, t)
+	}
+	return NewPGSQLSchema(s.SchemaName, s.Locales, ct.Sys.ID, types, 0).Exec(s.DatabaseURL)
+}
+
+func (s *PGWebhooks) deleteContentType(e *WebhookEvent) error {
+	if e.Sys == nil {
+		return fmt.Errorf("missing sys in %s payload", e.Topic)
+	}
+	err := NewPGDeleteContentType(s.SchemaName, e.Sys).Exec(s.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	delete(s.types, e.Sys.ID)
+	return nil
+}
+
+func (s *PGWebhooks) refresh(tableName string) (string, error) {
+	if !s.RefreshMatViews {
+		return "", nil
+	}
+	matViews := NewPGMatViews(&PGSQLSchema{Locales: s.Locales})
+	return matViews.ExecPublish(s.DatabaseURL, s.SchemaName, tableName)
+}